@@ -1,11 +1,18 @@
 package main
 
 import (
+	"os"
+
+	"git-genius/internal/cli"
 	"git-genius/internal/menu"
 	"git-genius/internal/system"
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		os.Exit(cli.Run(os.Args[1:]))
+	}
+
 	system.EnsureGitInstalled()
 	system.EnsureGitRepo()
 	system.CheckInternet()
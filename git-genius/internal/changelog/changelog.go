@@ -0,0 +1,278 @@
+package changelog
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"git-genius/internal/commitmsg"
+	"git-genius/internal/config"
+	"git-genius/internal/system"
+)
+
+// Entry is one commit as it appears in the changelog
+type Entry struct {
+	Hash     string `json:"hash"`
+	Short    string `json:"short"`
+	Type     string `json:"type,omitempty"`
+	Scope    string `json:"scope,omitempty"`
+	Subject  string `json:"subject"`
+	Breaking bool   `json:"breaking,omitempty"`
+	Refs     []int  `json:"refs,omitempty"` // PR / issue numbers
+}
+
+// Section groups entries of one kind ("Features", "Bug Fixes", ...)
+type Section struct {
+	Title   string  `json:"title"`
+	Entries []Entry `json:"entries"`
+}
+
+// Release is the changelog for one range of commits
+type Release struct {
+	Version  string    `json:"version"`
+	Date     string    `json:"date"`
+	From     string    `json:"from,omitempty"` // empty = start of history
+	To       string    `json:"to"`
+	Sections []Section `json:"sections"`
+}
+
+// sections lists the headings in output order; types not listed go to "Other"
+var sections = []struct {
+	title string
+	types []string
+}{
+	{"Features", []string{"feat"}},
+	{"Bug Fixes", []string{"fix"}},
+	{"Performance", []string{"perf"}},
+	{"Reverts", []string{"revert"}},
+	{"Documentation", []string{"docs"}},
+	{"Refactoring", []string{"refactor", "style"}},
+	{"Build & CI", []string{"build", "ci"}},
+	{"Tests", []string{"test"}},
+	{"Chores", []string{"chore"}},
+}
+
+const (
+	breakingTitle = "Breaking Changes"
+	otherTitle    = "Other"
+)
+
+var refPattern = regexp.MustCompile(`(?:^|[\s(,])#(\d+)\b`)
+
+/* ============================================================
+   Collecting
+   ============================================================ */
+
+/*
+Collect builds the release for the commits in from..to (merges skipped).
+An empty from means the whole history up to to.
+*/
+func Collect(version, from, to string) (Release, error) {
+	rng := to
+	if from != "" {
+		rng = from + ".." + to
+	}
+
+	out, err := system.GitOutput("log", "--no-merges",
+		"--format=%x1e%H%x1f%h%x1f%B", rng)
+	if err != nil {
+		return Release{}, err
+	}
+
+	var entries []Entry
+	for _, block := range strings.Split(out, "\x1e")[1:] {
+		f := strings.SplitN(block, "\x1f", 3)
+		if len(f) < 3 {
+			continue
+		}
+		entries = append(entries, newEntry(f[0], f[1], f[2]))
+	}
+
+	date, _ := system.GitQuery("log", "-1", "--format=%cs", to)
+
+	return Release{
+		Version:  version,
+		Date:     date,
+		From:     from,
+		To:       to,
+		Sections: group(entries),
+	}, nil
+}
+
+func newEntry(hash, short, msg string) Entry {
+	e := Entry{Hash: hash, Short: short}
+
+	if c, ok := commitmsg.Parse(msg); ok {
+		e.Type, e.Scope, e.Subject, e.Breaking = c.Type, c.Scope, c.Subject, c.Breaking
+	} else {
+		e.Subject = firstLine(msg)
+	}
+
+	seen := map[int]bool{}
+	for _, m := range refPattern.FindAllStringSubmatch(msg, -1) {
+		n, err := strconv.Atoi(m[1])
+		if err == nil && !seen[n] {
+			seen[n] = true
+			e.Refs = append(e.Refs, n)
+		}
+	}
+	sort.Ints(e.Refs)
+
+	return e
+}
+
+// group sorts entries into sections; breaking changes get their own, first
+func group(entries []Entry) []Section {
+	byTitle := map[string][]Entry{}
+	var breaking []Entry
+
+	for _, e := range entries {
+		if e.Breaking {
+			breaking = append(breaking, e)
+			continue
+		}
+		byTitle[sectionOf(e.Type)] = append(byTitle[sectionOf(e.Type)], e)
+	}
+
+	var out []Section
+	if len(breaking) > 0 {
+		out = append(out, Section{breakingTitle, breaking})
+	}
+	for _, s := range sections {
+		if len(byTitle[s.title]) > 0 {
+			out = append(out, Section{s.title, byTitle[s.title]})
+		}
+	}
+	if len(byTitle[otherTitle]) > 0 {
+		out = append(out, Section{otherTitle, byTitle[otherTitle]})
+	}
+	return out
+}
+
+func sectionOf(typ string) string {
+	for _, s := range sections {
+		for _, t := range s.types {
+			if t == typ {
+				return s.title
+			}
+		}
+	}
+	return otherTitle
+}
+
+// Empty reports whether the release has no commits
+func (r Release) Empty() bool {
+	return len(r.Sections) == 0
+}
+
+/* ============================================================
+   Tags
+   ============================================================ */
+
+// PreviousTag returns the latest tag before ref ("" when there is none).
+// If ref itself is tagged, the tag before it is returned.
+func PreviousTag(ref string) string {
+	if _, ok := system.GitQuery("describe", "--tags", "--exact-match", ref); ok {
+		ref += "^"
+	}
+	tag, _ := system.GitQuery("describe", "--tags", "--abbrev=0", ref)
+	return tag
+}
+
+// TagAt returns the tag pointing exactly at ref, if any
+func TagAt(ref string) string {
+	tag, _ := system.GitQuery("describe", "--tags", "--exact-match", ref)
+	return tag
+}
+
+/* ============================================================
+   Output
+   ============================================================ */
+
+/*
+Markdown renders the release as a CHANGELOG.md section. When Owner/Repo
+are configured, commits, PRs/issues and the compare range are linked.
+*/
+func Markdown(r Release, cfg config.Config) string {
+	base := ""
+	if cfg.Owner != "" && cfg.Repo != "" {
+		base = "https://github.com/" + cfg.Owner + "/" + cfg.Repo
+	}
+
+	var b strings.Builder
+
+	title := r.Version
+	if base != "" && r.From != "" {
+		to := r.To
+		if TagAt(to) == "" && r.Version != "Unreleased" {
+			to = r.Version
+		}
+		title = "[" + r.Version + "](" + base + "/compare/" + r.From + "..." + to + ")"
+	}
+	b.WriteString("## " + title)
+	if r.Date != "" {
+		b.WriteString(" (" + r.Date + ")")
+	}
+	b.WriteString("\n")
+
+	for _, s := range r.Sections {
+		b.WriteString("\n### " + s.Title + "\n\n")
+		for _, e := range s.Entries {
+			b.WriteString("- " + line(e, base) + "\n")
+		}
+	}
+	return b.String()
+}
+
+func line(e Entry, base string) string {
+	var b strings.Builder
+
+	if e.Scope != "" {
+		b.WriteString("**" + e.Scope + ":** ")
+	}
+	b.WriteString(linkRefs(e.Subject, base))
+
+	if base != "" {
+		b.WriteString(" ([" + e.Short + "](" + base + "/commit/" + e.Hash + "))")
+	} else {
+		b.WriteString(" (" + e.Short + ")")
+	}
+
+	// Refs only mentioned in the body are appended
+	for _, n := range e.Refs {
+		ref := "#" + strconv.Itoa(n)
+		if strings.Contains(e.Subject, ref) {
+			continue
+		}
+		b.WriteString(", " + linkRefs(ref, base))
+	}
+	return b.String()
+}
+
+// linkRefs turns #123 into a link; GitHub redirects issue URLs to PRs
+func linkRefs(s, base string) string {
+	if base == "" {
+		return s
+	}
+	return refPattern.ReplaceAllStringFunc(s, func(m string) string {
+		i := strings.Index(m, "#")
+		n := m[i+1:]
+		return m[:i] + "[#" + n + "](" + base + "/issues/" + n + ")"
+	})
+}
+
+// JSON renders the release for tooling
+func JSON(r Release) ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+/* ============================================================
+   Helpers
+   ============================================================ */
+
+func firstLine(s string) string {
+	l, _, _ := strings.Cut(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(l)
+}
@@ -0,0 +1,199 @@
+package changelog
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"git-genius/internal/config"
+	"git-genius/internal/system"
+	"git-genius/internal/ui"
+)
+
+// Default output files, relative to the repository root
+const (
+	DefaultFile     = "CHANGELOG.md"
+	DefaultJSONFile = "changelog.json"
+)
+
+const fileTitle = "# Changelog"
+
+// Options describes one changelog generation
+type Options struct {
+	Version  string // section title, e.g. v1.4.0 or Unreleased
+	From, To string // commit range; empty From = whole history
+	File     string // Markdown output ("" = don't write)
+	JSONFile string // JSON output ("" = don't write)
+}
+
+/*
+Generate collects the release for o and writes the requested files.
+An existing section for the same version is replaced, otherwise the new
+section is prepended below the file title.
+*/
+func Generate(o Options) (Release, error) {
+	r, err := Collect(o.Version, o.From, o.To)
+	if err != nil || r.Empty() {
+		return r, err
+	}
+
+	if o.File != "" {
+		if err := Write(inRepo(o.File), Markdown(r, config.Load())); err != nil {
+			return r, err
+		}
+	}
+	if o.JSONFile != "" {
+		data, err := JSON(r)
+		if err != nil {
+			return r, err
+		}
+		if err := os.WriteFile(inRepo(o.JSONFile), append(data, '\n'), 0644); err != nil {
+			return r, err
+		}
+	}
+	return r, nil
+}
+
+// Write puts a rendered release section into the Markdown file at path
+func Write(path, section string) error {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return os.WriteFile(path, []byte(fileTitle+"\n\n"+section), 0644)
+	}
+	if err != nil {
+		return err
+	}
+
+	lines := strings.Split(string(data), "\n")
+	head := strings.TrimPrefix(firstLine(section), "## ")
+
+	// Replace the section for the same version
+	if start := findSection(lines, head); start >= 0 {
+		end := start + 1
+		for end < len(lines) && !strings.HasPrefix(lines[end], "## ") {
+			end++
+		}
+		out := append([]string{}, lines[:start]...)
+		out = append(out, strings.Split(section, "\n")...)
+		out = append(out, lines[end:]...)
+		return os.WriteFile(path, []byte(strings.Join(out, "\n")), 0644)
+	}
+
+	// Prepend below the title (and any intro text before the first release)
+	at := 0
+	for at < len(lines) && !strings.HasPrefix(lines[at], "## ") {
+		at++
+	}
+	for at == len(lines) && at > 0 && strings.TrimSpace(lines[at-1]) == "" {
+		lines = lines[:at-1]
+		at--
+	}
+
+	out := append([]string{}, lines[:at]...)
+	if at > 0 && strings.TrimSpace(out[at-1]) != "" {
+		out = append(out, "")
+	}
+	out = append(out, strings.Split(section, "\n")...)
+	out = append(out, lines[at:]...)
+	return os.WriteFile(path, []byte(strings.Join(out, "\n")), 0644)
+}
+
+// findSection returns the line of the "## <version>" heading for the
+// version in head, or -1
+func findSection(lines []string, head string) int {
+	version := versionOf(head)
+	for i, l := range lines {
+		if strings.HasPrefix(l, "## ") && versionOf(strings.TrimPrefix(l, "## ")) == version {
+			return i
+		}
+	}
+	return -1
+}
+
+// versionOf extracts the version from a heading like "[v1.2.0](url) (date)"
+func versionOf(head string) string {
+	if strings.HasPrefix(head, "[") {
+		if i := strings.Index(head, "]"); i > 0 {
+			return head[1:i]
+		}
+	}
+	v, _, _ := strings.Cut(head, " ")
+	return v
+}
+
+func inRepo(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(system.RepoRoot(), path)
+}
+
+/* ============================================================
+   Interactive
+   ============================================================ */
+
+// Run asks for a range, previews the changelog and writes it on request
+func Run() {
+	ui.Header("Changelog")
+
+	to := ui.Input("Up to ref [HEAD]")
+	if to == "" {
+		to = "HEAD"
+	}
+	if _, ok := system.GitQuery("rev-parse", "--verify", "--quiet", to+"^{commit}"); !ok {
+		ui.Error("Unknown ref: " + to)
+		return
+	}
+
+	prev := PreviousTag(to)
+	label := prev
+	if label == "" {
+		label = "start of history"
+	}
+	from := ui.Input("From ref [" + label + "]")
+	if from == "" {
+		from = prev
+	}
+
+	version := TagAt(to)
+	if version == "" {
+		version = "Unreleased"
+	}
+	if v := ui.Input("Version title [" + version + "]"); v != "" {
+		version = v
+	}
+
+	r, err := Collect(version, from, to)
+	if err != nil {
+		ui.Error("Could not read commits")
+		return
+	}
+	if r.Empty() {
+		ui.Warn("No commits in this range")
+		return
+	}
+
+	ui.Page(strings.Split(Markdown(r, config.Load()), "\n"))
+
+	if ui.Confirm("Write to " + DefaultFile + "?") {
+		if err := Write(inRepo(DefaultFile), Markdown(r, config.Load())); err != nil {
+			ui.Error("Failed to write " + DefaultFile + ": " + err.Error())
+			system.LogError("changelog write", err)
+			return
+		}
+		ui.Success(DefaultFile + " updated")
+	}
+
+	if ui.Confirm("Also write " + DefaultJSONFile + "?") {
+		data, err := JSON(r)
+		if err == nil {
+			err = os.WriteFile(inRepo(DefaultJSONFile), append(data, '\n'), 0644)
+		}
+		if err != nil {
+			ui.Error("Failed to write " + DefaultJSONFile + ": " + err.Error())
+			system.LogError("changelog json", err)
+			return
+		}
+		ui.Success(DefaultJSONFile + " written")
+	}
+}
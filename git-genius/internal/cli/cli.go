@@ -0,0 +1,397 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"git-genius/internal/changelog"
+	"git-genius/internal/commitmsg"
+	"git-genius/internal/config"
+	"git-genius/internal/gitignore"
+	"git-genius/internal/gitops"
+	"git-genius/internal/hooks"
+	"git-genius/internal/release"
+	"git-genius/internal/system"
+	"git-genius/internal/ui"
+)
+
+/*
+Run executes a non-interactive command given on the command line and
+returns the process exit code. Without arguments the menu is used.
+*/
+func Run(args []string) int {
+	ui.Batch = true
+
+	switch args[0] {
+	case "push":
+		return push(args[1:])
+	case "check-message":
+		return checkMessage(args[1:])
+	case "changelog":
+		return changelogCmd(args[1:])
+	case "version":
+		return versionCmd(args[1:])
+	case "scan":
+		return scan()
+	case "gitignore":
+		return gitignoreCmd(args[1:])
+	case "hook", "hooks":
+		return hookCmd(args[1:])
+	case "help", "-h", "--help":
+		usage()
+		return 0
+	}
+
+	ui.Error("Unknown command: " + args[0])
+	usage()
+	return 2
+}
+
+func usage() {
+	fmt.Println("Usage: genius [command]")
+	fmt.Println()
+	fmt.Println("Without a command the interactive menu starts.")
+	fmt.Println()
+	fmt.Println("Commands:")
+	fmt.Println("  push -m <message>        stage all, commit and push the current branch")
+	fmt.Println("  check-message <message>  validate a commit message against the configured rules")
+	fmt.Println("      --file <path>        read the message from a file (commit-msg hook)")
+	fmt.Println("  changelog [options]      write CHANGELOG.md from commits since the last tag")
+	fmt.Println("      --from <ref>         start of the range (default: previous tag)")
+	fmt.Println("      --to <ref>           end of the range (default: HEAD)")
+	fmt.Println("      --version <name>     section title (default: tag at --to, or Unreleased)")
+	fmt.Println("      --output <file>      Markdown file (default: CHANGELOG.md)")
+	fmt.Println("      --json <file>        also write the changelog as JSON")
+	fmt.Println("      --stdout             print Markdown instead of writing files")
+	fmt.Println("  version                  show the current version and the suggested next one")
+	fmt.Println("  version bump [level]     tag the next version (level: auto, major, minor, patch)")
+	fmt.Println("      --pre <id>           make a pre-release, e.g. rc → v1.2.0-rc.1")
+	fmt.Println("      --changelog          update CHANGELOG.md in the release commit")
+	fmt.Println("      --no-push            commit and tag locally only")
+	fmt.Println("      --dry-run            show the plan without changing anything")
+	fmt.Println("  scan                     check staged changes for secrets (exit 1 when found)")
+	fmt.Println("  gitignore [template...]  add .gitignore rules (default: detected project types)")
+	fmt.Println("      --list               show available templates")
+	fmt.Println("      --untrack            stop tracking files that are ignored now")
+	fmt.Println("  hook list                show hooks and their configured commands")
+	fmt.Println("  hook init                create " + hooks.ConfigFile + " with sample checks")
+	fmt.Println("  hook install [name...]   enable genius-managed hooks (default: all)")
+	fmt.Println("  hook uninstall [name...] disable them again")
+	fmt.Println("  hook run <name> [args]   run a hook's commands (called by the hook scripts)")
+	fmt.Println("  help                     show this help")
+	fmt.Println()
+	fmt.Println("Set GENIUS_ALLOW_SECRETS=1 to commit despite secret scanner findings,")
+	fmt.Println("and GENIUS_ALLOW_LARGE=1 to commit files above the size limit.")
+}
+
+/* ============================================================
+   Commands
+   ============================================================ */
+
+func push(args []string) int {
+	msg := messageArg(args)
+	if msg == "" {
+		ui.Error("Usage: genius push -m <message>")
+		return 2
+	}
+
+	system.EnsureGitInstalled()
+	system.CheckInternet()
+
+	if !gitops.Push(msg) {
+		return 1
+	}
+	return 0
+}
+
+func checkMessage(args []string) int {
+	msg := strings.Join(args, " ")
+	if len(args) == 2 && args[0] == "--file" {
+		data, err := os.ReadFile(args[1])
+		if err != nil {
+			ui.Error("Cannot read message file: " + err.Error())
+			return 2
+		}
+		msg = stripComments(string(data))
+	}
+	if err := commitmsg.Validate(msg, config.Load()); err != nil {
+		ui.Error("Invalid commit message: " + err.Error())
+		return 1
+	}
+	ui.Success("Commit message is valid")
+	return 0
+}
+
+func scan() int {
+	findings, err := gitops.ScanStaged()
+	if err != nil {
+		ui.Error("Could not read staged changes")
+		return 2
+	}
+	if len(findings) == 0 {
+		ui.Success("No secrets found in staged changes")
+		return 0
+	}
+
+	ui.Error(fmt.Sprintf("Possible secrets found in %d place(s):", len(findings)))
+	gitops.PrintFindings(findings)
+
+	// Set by the user, or by genius for a commit they approved
+	if os.Getenv("GENIUS_ALLOW_SECRETS") == "1" {
+		ui.Warn("GENIUS_ALLOW_SECRETS=1 is set — not blocking")
+		return 0
+	}
+	return 1
+}
+
+func gitignoreCmd(args []string) int {
+	fs := flag.NewFlagSet("gitignore", flag.ContinueOnError)
+	list := fs.Bool("list", false, "")
+	untrack := fs.Bool("untrack", false, "")
+	fs.Usage = usage
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if *list {
+		for _, t := range gitignore.Templates {
+			fmt.Printf("  %-8s %s\n", t.Key, t.Name)
+		}
+		return 0
+	}
+
+	tpls := gitignore.Detect(system.WorkDir())
+	if fs.NArg() > 0 {
+		tpls = nil
+		for _, k := range fs.Args() {
+			t, ok := gitignore.Lookup(k)
+			if !ok {
+				ui.Error("Unknown template: " + k + " (see genius gitignore --list)")
+				return 2
+			}
+			tpls = append(tpls, t)
+		}
+	}
+
+	root := system.RepoRoot()
+	if add := gitignore.Merge(gitignore.Read(root), tpls); add != "" {
+		if err := gitignore.Append(root, add); err != nil {
+			ui.Error("Could not write .gitignore: " + err.Error())
+			return 1
+		}
+		ui.Success(fmt.Sprintf(".gitignore updated (%d template(s))", len(tpls)))
+	} else {
+		ui.Success(".gitignore already up to date")
+	}
+
+	if files := gitignore.TrackedIgnored(); len(files) > 0 {
+		if !*untrack {
+			ui.Warn(fmt.Sprintf("%d tracked file(s) are ignored now — rerun with --untrack to remove them from the index", len(files)))
+			return 0
+		}
+		if err := gitignore.Untrack(files); err != nil {
+			ui.Error("Failed to untrack files")
+			return 1
+		}
+		ui.Success(fmt.Sprintf("Untracked %d file(s)", len(files)))
+	}
+	return 0
+}
+
+func hookCmd(args []string) int {
+	if len(args) == 0 {
+		ui.Error("Usage: genius hook list|init|install|uninstall|run")
+		return 2
+	}
+
+	switch args[0] {
+	case "list":
+		hooks.List()
+		return 0
+
+	case "init":
+		if _, err := os.Stat(hooks.ConfigPath()); err == nil {
+			ui.Error(hooks.ConfigFile + " already exists")
+			return 1
+		}
+		if err := hooks.Save(hooks.Sample()); err != nil {
+			ui.Error("Could not write " + hooks.ConfigFile + ": " + err.Error())
+			return 1
+		}
+		ui.Success("Created " + hooks.ConfigFile)
+		return 0
+
+	case "install", "uninstall":
+		names := args[1:]
+		if len(names) == 0 {
+			names = hooks.Names
+		}
+		apply := hooks.Install
+		if args[0] == "uninstall" {
+			apply = hooks.Uninstall
+		}
+		for _, n := range names {
+			if err := apply(n); err != nil {
+				ui.Error(n + ": " + err.Error())
+				return 1
+			}
+			ui.Success(args[0] + "ed " + n)
+		}
+		return 0
+
+	case "run":
+		if len(args) < 2 {
+			ui.Error("Usage: genius hook run <name> [args]")
+			return 2
+		}
+		var refs io.Reader
+		if args[1] == "pre-push" {
+			refs = os.Stdin
+		}
+		if !hooks.Run(args[1], args[2:], refs) {
+			return 1
+		}
+		return 0
+	}
+
+	ui.Error("Unknown hook command: " + args[0])
+	return 2
+}
+
+func changelogCmd(args []string) int {
+	fs := flag.NewFlagSet("changelog", flag.ContinueOnError)
+	from := fs.String("from", "", "")
+	to := fs.String("to", "HEAD", "")
+	version := fs.String("version", "", "")
+	output := fs.String("output", changelog.DefaultFile, "")
+	jsonFile := fs.String("json", "", "")
+	stdout := fs.Bool("stdout", false, "")
+	fs.Usage = usage
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if *from == "" {
+		*from = changelog.PreviousTag(*to)
+	}
+	if *version == "" {
+		*version = changelog.TagAt(*to)
+	}
+	if *version == "" {
+		*version = "Unreleased"
+	}
+
+	if *stdout {
+		r, err := changelog.Collect(*version, *from, *to)
+		if err != nil {
+			ui.Error("Could not read commits")
+			return 1
+		}
+		fmt.Print(changelog.Markdown(r, config.Load()))
+		return 0
+	}
+
+	r, err := changelog.Generate(changelog.Options{
+		Version:  *version,
+		From:     *from,
+		To:       *to,
+		File:     *output,
+		JSONFile: *jsonFile,
+	})
+	if err != nil {
+		ui.Error("Changelog generation failed: " + err.Error())
+		return 1
+	}
+	if r.Empty() {
+		ui.Warn("No commits in this range")
+		return 0
+	}
+	ui.Success("Changelog written to " + *output)
+	return 0
+}
+
+func versionCmd(args []string) int {
+	if len(args) == 0 {
+		p, err := release.NewPlan(release.Auto, "")
+		if err != nil {
+			ui.Error("Could not read commit history")
+			return 1
+		}
+		p.Show()
+		return 0
+	}
+	if args[0] != "bump" {
+		ui.Error("Usage: genius version [bump [level] [options]]")
+		return 2
+	}
+
+	fs := flag.NewFlagSet("version bump", flag.ContinueOnError)
+	pre := fs.String("pre", "", "")
+	withChangelog := fs.Bool("changelog", false, "")
+	noPush := fs.Bool("no-push", false, "")
+	dryRun := fs.Bool("dry-run", false, "")
+	fs.Usage = usage
+
+	// Level may come before or after the flags
+	level := release.Auto
+	rest := args[1:]
+	if len(rest) > 0 && !strings.HasPrefix(rest[0], "-") {
+		level, rest = rest[0], rest[1:]
+	}
+	if err := fs.Parse(rest); err != nil {
+		return 2
+	}
+	if fs.NArg() > 0 {
+		level = fs.Arg(0)
+	}
+
+	p, err := release.NewPlan(level, *pre)
+	if err != nil {
+		ui.Error(err.Error())
+		return 2
+	}
+	p.Changelog = *withChangelog
+	p.Push = !*noPush
+
+	p.Show()
+	if *dryRun {
+		return 0
+	}
+
+	if p.Push {
+		system.CheckInternet()
+	}
+	if !p.Apply() {
+		return 1
+	}
+	return 0
+}
+
+/* ============================================================
+   Helpers
+   ============================================================ */
+
+// stripComments drops git's "#" comment lines from a message file
+func stripComments(msg string) string {
+	var keep []string
+	for _, l := range strings.Split(msg, "\n") {
+		if strings.HasPrefix(l, "# ------------------------ >8") {
+			break // everything below is the verbose diff
+		}
+		if !strings.HasPrefix(l, "#") {
+			keep = append(keep, l)
+		}
+	}
+	return strings.TrimSpace(strings.Join(keep, "\n"))
+}
+
+// messageArg accepts "-m <msg>" or the message as plain arguments
+func messageArg(args []string) string {
+	if len(args) >= 2 && (args[0] == "-m" || args[0] == "--message") {
+		return strings.Join(args[1:], " ")
+	}
+	return strings.Join(args, " ")
+}
@@ -0,0 +1,177 @@
+package commitmsg
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"git-genius/internal/config"
+)
+
+// Commit message styles accepted by config.CommitStyle
+const (
+	StyleFree         = "free"
+	StyleConventional = "conventional"
+	StyleRegex        = "regex"
+)
+
+// DefaultTypes are the Conventional Commit types allowed when none are configured
+var DefaultTypes = []string{
+	"feat", "fix", "docs", "style", "refactor", "perf",
+	"test", "build", "ci", "chore", "revert",
+}
+
+// MaxSubject is the longest subject line accepted for Conventional Commits
+const MaxSubject = 72
+
+var header = regexp.MustCompile(`^([a-zA-Z]+)(\(([^()\s]+)\))?(!)?: (.+)$`)
+
+// Message is a parsed Conventional Commit
+type Message struct {
+	Type     string
+	Scope    string
+	Breaking bool
+	Subject  string
+	Body     string
+	Footers  []string
+}
+
+/* ============================================================
+   Parsing
+   ============================================================ */
+
+// Parse reads a Conventional Commit; ok is false when the header does not match
+func Parse(msg string) (Message, bool) {
+	msg = strings.TrimSpace(msg)
+	first, rest, _ := strings.Cut(msg, "\n")
+
+	m := header.FindStringSubmatch(strings.TrimSpace(first))
+	if m == nil {
+		return Message{}, false
+	}
+
+	c := Message{
+		Type:     strings.ToLower(m[1]),
+		Scope:    m[3],
+		Breaking: m[4] == "!",
+		Subject:  m[5],
+	}
+
+	var body []string
+	for _, l := range strings.Split(strings.TrimSpace(rest), "\n") {
+		if isFooter(l) {
+			c.Footers = append(c.Footers, l)
+			if strings.HasPrefix(l, "BREAKING CHANGE") || strings.HasPrefix(l, "BREAKING-CHANGE") {
+				c.Breaking = true
+			}
+			continue
+		}
+		body = append(body, l)
+	}
+	c.Body = strings.TrimSpace(strings.Join(body, "\n"))
+
+	return c, true
+}
+
+var footer = regexp.MustCompile(`^([A-Za-z-]+|BREAKING CHANGE)(: | #)`)
+
+func isFooter(l string) bool {
+	return footer.MatchString(l)
+}
+
+// String formats the message back into commit text
+func (c Message) String() string {
+	var b strings.Builder
+
+	b.WriteString(c.Type)
+	if c.Scope != "" {
+		b.WriteString("(" + c.Scope + ")")
+	}
+	if c.Breaking {
+		b.WriteString("!")
+	}
+	b.WriteString(": " + c.Subject)
+
+	if c.Body != "" {
+		b.WriteString("\n\n" + c.Body)
+	}
+	if len(c.Footers) > 0 {
+		b.WriteString("\n\n" + strings.Join(c.Footers, "\n"))
+	}
+	return b.String()
+}
+
+/* ============================================================
+   Validation
+   ============================================================ */
+
+// Types returns the allowed Conventional Commit types for cfg
+func Types(cfg config.Config) []string {
+	if len(cfg.CommitTypes) > 0 {
+		return cfg.CommitTypes
+	}
+	return DefaultTypes
+}
+
+// generated matches subjects git writes itself (merges, reverts, autosquash)
+var generated = regexp.MustCompile(`^(Merge (branch|remote-tracking branch|tag|commit|pull request) |Merge [0-9a-f]{7,} into |Revert "|Reapply "|(fixup|squash|amend)! )`)
+
+// Generated reports whether a subject line was produced by git, like
+// "Merge branch 'x'" or "fixup! feat: y"; these skip validation
+func Generated(subject string) bool {
+	return generated.MatchString(subject)
+}
+
+// Validate checks msg against the rules stored in cfg
+func Validate(msg string, cfg config.Config) error {
+	msg = strings.TrimSpace(msg)
+	if msg == "" {
+		return errors.New("commit message cannot be empty")
+	}
+
+	first, _, _ := strings.Cut(msg, "\n")
+	if Generated(first) {
+		return nil
+	}
+
+	switch cfg.CommitStyle {
+	case StyleConventional:
+		c, ok := Parse(msg)
+		if !ok {
+			return errors.New("message must look like: type(scope): subject")
+		}
+		if !contains(Types(cfg), c.Type) {
+			return fmt.Errorf("unknown type %q (allowed: %s)", c.Type, strings.Join(Types(cfg), ", "))
+		}
+		if len(first) > MaxSubject {
+			return fmt.Errorf("first line is %d characters (max %d)", len(first), MaxSubject)
+		}
+		if strings.HasSuffix(c.Subject, ".") {
+			return errors.New("subject should not end with a period")
+		}
+
+	case StyleRegex:
+		if cfg.CommitPattern == "" {
+			return nil
+		}
+		re, err := regexp.Compile(cfg.CommitPattern)
+		if err != nil {
+			return fmt.Errorf("invalid commit_pattern in config: %v", err)
+		}
+		if !re.MatchString(first) {
+			return fmt.Errorf("first line must match %s", cfg.CommitPattern)
+		}
+	}
+
+	return nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
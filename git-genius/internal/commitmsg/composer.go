@@ -0,0 +1,78 @@
+package commitmsg
+
+import (
+	"fmt"
+	"strings"
+
+	"git-genius/internal/config"
+	"git-genius/internal/ui"
+)
+
+/*
+Compose builds a Conventional Commit step by step: type, scope, subject,
+body, breaking change and issue references. A suggested message (may be
+empty) pre-fills the answers. It returns false when the user cancels.
+*/
+func Compose(cfg config.Config, suggestion string) (string, bool) {
+	ui.Header("Commit Composer")
+
+	hint, _ := Parse(suggestion)
+	if hint.Type != "" {
+		ui.Info("Suggested type: " + hint.Type)
+	}
+
+	types := Types(cfg)
+	i := ui.Select("Type", types)
+	if i < 0 {
+		return "", false
+	}
+
+	c := Message{Type: types[i]}
+	c.Scope = ui.Input("Scope (optional, e.g. ui, api) [" + hint.Scope + "]")
+	if c.Scope == "" {
+		c.Scope = hint.Scope
+	}
+
+	for c.Subject == "" {
+		c.Subject = strings.TrimSuffix(ui.Input("Subject (imperative, e.g. add login screen) ["+hint.Subject+"]"), ".")
+		if c.Subject == "" {
+			c.Subject = hint.Subject
+		}
+		if c.Subject == "" {
+			if !ui.Confirm("Subject is required. Try again?") {
+				return "", false
+			}
+		}
+	}
+
+	c.Body = ui.Input("Body (optional, single line)")
+
+	if ui.Confirm("Is this a breaking change?") {
+		c.Breaking = true
+		if desc := ui.Input("Describe the breaking change"); desc != "" {
+			c.Footers = append(c.Footers, "BREAKING CHANGE: "+desc)
+		}
+	}
+
+	if refs := ui.Input("Issues it closes (e.g. 12 34, optional)"); refs != "" {
+		for _, r := range strings.Fields(strings.ReplaceAll(refs, ",", " ")) {
+			c.Footers = append(c.Footers, "Closes #"+strings.TrimPrefix(r, "#"))
+		}
+	}
+	if refs := ui.Input("Issues it references (optional)"); refs != "" {
+		for _, r := range strings.Fields(strings.ReplaceAll(refs, ",", " ")) {
+			c.Footers = append(c.Footers, "Refs #"+strings.TrimPrefix(r, "#"))
+		}
+	}
+
+	msg := c.String()
+	fmt.Println()
+	ui.Info("Commit message:")
+	fmt.Println(msg)
+	fmt.Println()
+
+	if !ui.Confirm("Use this message?") {
+		return "", false
+	}
+	return msg, true
+}
@@ -0,0 +1,284 @@
+package commitmsg
+
+import (
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"git-genius/internal/config"
+	"git-genius/internal/diff"
+)
+
+// funcDef matches function definitions in common languages
+var funcDef = []*regexp.Regexp{
+	regexp.MustCompile(`^\s*func\s+(?:\([^)]*\)\s*)?([A-Za-z_]\w*)\s*[\[(]`),                      // Go
+	regexp.MustCompile(`^\s*(?:async\s+)?def\s+([A-Za-z_]\w*)\s*\(`),                              // Python
+	regexp.MustCompile(`^\s*(?:export\s+)?(?:async\s+)?function\s*\*?\s*([A-Za-z_$][\w$]*)\s*\(`), // JS/TS
+	regexp.MustCompile(`^\s*(?:export\s+)?(?:const|let)\s+([A-Za-z_$][\w$]*)\s*=\s*(?:async\s*)?\([^)]*\)\s*=>`),
+	regexp.MustCompile(`^\s*(?:\w+\s+)*fun\s+(?:<[^>]*>\s*)?(?:\w+\.)?([A-Za-z_]\w*)\s*\(`),                                 // Kotlin
+	regexp.MustCompile(`^\s*(?:public|private|protected)\s+(?:static\s+)?(?:final\s+)?[\w<>\[\], ]+\s+([A-Za-z_]\w*)\s*\(`), // Java
+	regexp.MustCompile(`^\s*(?:pub\s+)?fn\s+([A-Za-z_]\w*)`),                                                                // Rust
+}
+
+// analysis is what the heuristics extract from a staged diff
+type analysis struct {
+	added, deleted, modified []string
+	renamed                  [][2]string
+	newFuncs, goneFuncs      []string
+	addLines, delLines       int
+}
+
+/*
+Suggest proposes a commit message for the staged diff using offline
+heuristics: file operations, added/removed functions, the dominant
+directory and the kind of files touched. It returns an empty string
+when there is nothing to describe.
+*/
+func Suggest(files []diff.File, cfg config.Config) string {
+	if len(files) == 0 {
+		return ""
+	}
+
+	a := analyze(files)
+	typ := guessType(a, files)
+	scope := dominantDir(files)
+
+	if cfg.CommitStyle == StyleConventional {
+		if !contains(Types(cfg), typ) {
+			typ = Types(cfg)[0]
+		}
+		// The scope already says where
+		return Message{Type: typ, Scope: scope, Subject: guessSubject(a, "")}.String()
+	}
+
+	subject := guessSubject(a, scope)
+	return strings.ToUpper(subject[:1]) + subject[1:]
+}
+
+func analyze(files []diff.File) analysis {
+	var a analysis
+
+	for _, f := range files {
+		switch {
+		case f.OldPath == "/dev/null":
+			a.added = append(a.added, f.NewPath)
+		case f.NewPath == "/dev/null":
+			a.deleted = append(a.deleted, f.OldPath)
+		case f.OldPath != f.NewPath:
+			a.renamed = append(a.renamed, [2]string{f.OldPath, f.NewPath})
+		default:
+			a.modified = append(a.modified, f.NewPath)
+		}
+
+		added, removed := map[string]bool{}, map[string]bool{}
+		for _, h := range f.Hunks {
+			for _, l := range h.Lines {
+				switch l.Kind {
+				case '+':
+					a.addLines++
+					if n := funcName(l.Text); n != "" {
+						added[n] = true
+					}
+				case '-':
+					a.delLines++
+					if n := funcName(l.Text); n != "" {
+						removed[n] = true
+					}
+				}
+			}
+		}
+
+		// A signature change shows up on both sides: not new, not gone
+		for n := range added {
+			if !removed[n] {
+				a.newFuncs = append(a.newFuncs, n)
+			}
+		}
+		for n := range removed {
+			if !added[n] {
+				a.goneFuncs = append(a.goneFuncs, n)
+			}
+		}
+	}
+
+	sort.Strings(a.newFuncs)
+	sort.Strings(a.goneFuncs)
+	return a
+}
+
+func funcName(line string) string {
+	for _, re := range funcDef {
+		if m := re.FindStringSubmatch(line); m != nil {
+			return m[1]
+		}
+	}
+	return ""
+}
+
+// guessType picks a Conventional Commit type from what was touched
+func guessType(a analysis, files []diff.File) string {
+	all := func(match func(string) bool) bool {
+		for _, f := range files {
+			if !match(f.Name()) {
+				return false
+			}
+		}
+		return true
+	}
+
+	switch {
+	case all(isDoc):
+		return "docs"
+	case all(isTest):
+		return "test"
+	case all(isCI):
+		return "ci"
+	case all(isBuild):
+		return "build"
+	case len(a.renamed) > 0 && len(a.added)+len(a.deleted)+len(a.modified) == 0:
+		return "refactor"
+	case len(a.deleted) > 0 && len(a.added)+len(a.modified)+len(a.renamed) == 0:
+		return "chore"
+	case len(a.newFuncs) > 0 || len(a.added) > 0:
+		return "feat"
+	case len(a.goneFuncs) > 0 || (a.addLines > 0 && a.delLines > 0 && ratio(a.addLines, a.delLines) < 1.5):
+		return "refactor"
+	}
+	return "fix"
+}
+
+// guessSubject writes a short imperative summary
+func guessSubject(a analysis, scope string) string {
+	total := len(a.added) + len(a.deleted) + len(a.modified) + len(a.renamed)
+
+	switch {
+	case len(a.renamed) == 1 && total == 1:
+		return "rename " + path.Base(a.renamed[0][0]) + " to " + path.Base(a.renamed[0][1])
+	case len(a.renamed) == total:
+		return "move " + plural(total, "file") + " to " + commonDir(a.renamed)
+	case len(a.deleted) == total:
+		if total == 1 {
+			return "remove " + path.Base(a.deleted[0])
+		}
+		return "remove " + plural(total, "file") + where(scope)
+	case len(a.newFuncs) > 0:
+		return "add " + listNames(a.newFuncs) + where(scope)
+	case len(a.added) == 1 && total == 1:
+		return "add " + path.Base(a.added[0])
+	case len(a.added) == total:
+		return "add " + plural(total, "file") + where(scope)
+	case len(a.goneFuncs) > 0:
+		return "remove " + listNames(a.goneFuncs) + where(scope)
+	case total == 1:
+		name := ""
+		if len(a.modified) == 1 {
+			name = a.modified[0]
+		} else if len(a.added) == 1 {
+			name = a.added[0]
+		}
+		return "update " + path.Base(name)
+	}
+	return "update " + plural(total, "file") + where(scope)
+}
+
+/* ============================================================
+   Helpers
+   ============================================================ */
+
+// dominantDir returns the directory touched by most files ("" for the root)
+func dominantDir(files []diff.File) string {
+	count := map[string]int{}
+	for _, f := range files {
+		dir := path.Dir(f.Name())
+		if dir == "." {
+			continue
+		}
+		// internal/gitops/x.go → gitops; src/app.js → src
+		parts := strings.Split(dir, "/")
+		count[parts[len(parts)-1]]++
+	}
+
+	best, n := "", 0
+	for d, c := range count {
+		if c > n || (c == n && d < best) {
+			best, n = d, c
+		}
+	}
+	if n*2 < len(files) {
+		return ""
+	}
+	return best
+}
+
+func commonDir(pairs [][2]string) string {
+	dir := path.Dir(pairs[0][1])
+	for _, p := range pairs[1:] {
+		if path.Dir(p[1]) != dir {
+			return "new locations"
+		}
+	}
+	return dir
+}
+
+func listNames(names []string) string {
+	switch len(names) {
+	case 1:
+		return names[0]
+	case 2:
+		return names[0] + " and " + names[1]
+	}
+	return names[0] + ", " + names[1] + " and " + plural(len(names)-2, "more")
+}
+
+func plural(n int, word string) string {
+	s := strconv.Itoa(n) + " " + word
+	if n != 1 && word != "more" {
+		s += "s"
+	}
+	return s
+}
+
+func where(scope string) string {
+	if scope == "" {
+		return ""
+	}
+	return " in " + scope
+}
+
+func ratio(a, b int) float64 {
+	if a < b {
+		a, b = b, a
+	}
+	return float64(a) / float64(b)
+}
+
+func isDoc(p string) bool {
+	ext := strings.ToLower(path.Ext(p))
+	return ext == ".md" || ext == ".rst" || ext == ".txt" || strings.HasPrefix(p, "docs/") ||
+		strings.EqualFold(path.Base(p), "LICENSE")
+}
+
+func isTest(p string) bool {
+	b := path.Base(p)
+	return strings.HasSuffix(b, "_test.go") || strings.Contains(b, ".test.") ||
+		strings.Contains(b, ".spec.") || strings.HasPrefix(b, "test_") ||
+		strings.Contains(p, "/test/") || strings.HasPrefix(p, "test/") || strings.HasPrefix(p, "tests/")
+}
+
+func isCI(p string) bool {
+	return strings.HasPrefix(p, ".github/") || strings.HasPrefix(p, ".gitlab-ci") ||
+		strings.HasPrefix(p, ".circleci/")
+}
+
+func isBuild(p string) bool {
+	switch path.Base(p) {
+	case "go.mod", "go.sum", "package.json", "package-lock.json", "yarn.lock",
+		"build.gradle", "build.gradle.kts", "settings.gradle", "pom.xml",
+		"Makefile", "Dockerfile", "requirements.txt", "pyproject.toml",
+		"pubspec.yaml", "pubspec.lock", "Cargo.toml", "Cargo.lock":
+		return true
+	}
+	return false
+}
@@ -15,12 +15,38 @@ type Config struct {
 	Branch string `json:"branch"`
 	Remote string `json:"remote"`
 
+	// Extra remotes every push is mirrored to (e.g. GitHub mirror + internal)
+	PushRemotes []string `json:"push_remotes,omitempty"`
+
 	// GitHub specific
 	Owner string `json:"owner"` // username or organisation
 	Repo  string `json:"repo"`  // repository name
+	Auth  string `json:"auth"`  // https (token) | ssh
 
 	// Project directory
 	WorkDir string `json:"work_dir"` // 👈 NEW: path of project to operate on
+
+	// Show the staged diff for review before each commit
+	ReviewDiff bool `json:"review_diff"`
+
+	// Commit message rules
+	CommitStyle   string   `json:"commit_style"`             // free | conventional | regex
+	CommitPattern string   `json:"commit_pattern,omitempty"` // first-line regex for commit_style=regex
+	CommitTypes   []string `json:"commit_types,omitempty"`   // allowed conventional types
+
+	// Staged files above this size (MB) trigger the large file guard; -1 = off
+	MaxFileMB int `json:"max_file_mb"`
+
+	// Commit and tag signing
+	Signing    string `json:"signing,omitempty"`     // "" (off) | gpg | ssh
+	SigningKey string `json:"signing_key,omitempty"` // gpg key id, or path to the ssh public key
+
+	// Files whose version string is updated by "version bump"
+	VersionFiles []string `json:"version_files,omitempty"`
+
+	// Pull behaviour
+	PullStrategy string `json:"pull_strategy"` // merge | rebase | ff-only
+	Autostash    bool   `json:"autostash"`     // stash local changes without asking
 }
 
 // Load reads config from .git/.genius/config.json
@@ -47,6 +73,18 @@ func Load() Config {
 		// empty means: use current directory
 		c.WorkDir = ""
 	}
+	if c.Auth == "" {
+		c.Auth = "https"
+	}
+	if c.PullStrategy == "" {
+		c.PullStrategy = "merge"
+	}
+	if c.CommitStyle == "" {
+		c.CommitStyle = "free"
+	}
+	if c.MaxFileMB == 0 {
+		c.MaxFileMB = 10
+	}
 
 	return c
 }
@@ -70,6 +108,11 @@ func defaultConfig() Config {
 		Remote:  "origin",
 		Owner:   "",
 		Repo:    "",
+		Auth:    "https",
 		WorkDir: "", // empty = current working directory
+
+		PullStrategy: "merge",
+		CommitStyle:  "free",
+		MaxFileMB:    10,
 	}
 }
@@ -0,0 +1,519 @@
+package conflict
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"git-genius/internal/system"
+	"git-genius/internal/ui"
+)
+
+// Operation is the git command that stopped on conflicts
+type Operation string
+
+const (
+	None       Operation = ""
+	Merge      Operation = "merge"
+	Rebase     Operation = "rebase"
+	CherryPick Operation = "cherry-pick"
+	Revert     Operation = "revert"
+)
+
+// file is a conflicted path with git's two-letter status code
+type file struct {
+	Path   string
+	Status string
+}
+
+/* ============================================================
+   Detection
+   ============================================================ */
+
+// InProgress reports which operation (if any) is waiting for resolution
+func InProgress() Operation {
+	switch {
+	case gitPathExists("rebase-merge"), gitPathExists("rebase-apply"):
+		return Rebase
+	case gitPathExists("MERGE_HEAD"):
+		return Merge
+	case gitPathExists("CHERRY_PICK_HEAD"):
+		return CherryPick
+	case gitPathExists("REVERT_HEAD"):
+		return Revert
+	}
+	return None
+}
+
+// Pending reports whether unmerged paths are waiting for resolution
+func Pending() bool {
+	return len(conflictedFiles()) > 0
+}
+
+// conflictedFiles lists unmerged paths (relative to the repository root)
+func conflictedFiles() []file {
+	out, ok := system.GitQuery("status", "--porcelain")
+	if !ok || out == "" {
+		return nil
+	}
+
+	var files []file
+	for _, l := range strings.Split(out, "\n") {
+		if len(l) < 4 {
+			continue
+		}
+		xy := l[:2]
+		switch xy {
+		case "DD", "AU", "UD", "UA", "DU", "AA", "UU":
+			files = append(files, file{Path: unquote(l[3:]), Status: xy})
+		}
+	}
+	return files
+}
+
+/* ============================================================
+   Assistant
+   ============================================================ */
+
+// BeforeChange runs before the assistant moves refs (continue, skip,
+// abort); gitops points it at its undo journal
+var BeforeChange = func(op string) {}
+
+/*
+Resolve runs the interactive conflict assistant for the operation in
+progress. It returns true once the operation has been completed, and
+false when it was aborted or left for later.
+*/
+func Resolve() bool {
+	for {
+		op := InProgress()
+		if op == None {
+			if len(conflictedFiles()) == 0 {
+				ui.Success("No conflicts to resolve")
+				return true
+			}
+			return resolveLoose()
+		}
+
+		files := conflictedFiles()
+
+		fmt.Println()
+		ui.Header("Conflict Assistant — " + string(op) + " in progress")
+		if op == Rebase {
+			ui.Info("During a rebase 'ours' is the upstream and 'theirs' is your commit")
+		}
+
+		if len(files) == 0 {
+			ui.Success("All conflicts resolved")
+		} else {
+			fmt.Println("Conflicted files:")
+			for i, f := range files {
+				fmt.Printf("%2d) %s %s\n", i+1, f.Path, ui.Yellow+"("+describe(f.Status)+")"+ui.Reset)
+			}
+		}
+
+		fmt.Println()
+		fmt.Println(" c) Continue " + string(op))
+		fmt.Println(" a) Abort " + string(op))
+		if op != Merge {
+			fmt.Println(" s) Skip this commit")
+		}
+		fmt.Println(" q) Leave for later")
+
+		choice := ui.Input("Select file number or action")
+		switch choice {
+		case "c":
+			if len(files) > 0 {
+				ui.Error("Resolve all conflicted files first")
+				continue
+			}
+			BeforeChange(string(op) + " --continue")
+			if cont(op) {
+				// A rebase may stop again on the next commit
+				if InProgress() == None {
+					ui.Success(op.title() + " completed")
+					return true
+				}
+			}
+		case "a":
+			if ui.Confirm("Abort the " + string(op) + " and discard its progress?") {
+				BeforeChange(string(op) + " --abort")
+				if err := system.RunGit(string(op), "--abort"); err != nil {
+					ui.Error("Abort failed (see error.log)")
+					continue
+				}
+				ui.Warn(op.title() + " aborted")
+				return false
+			}
+		case "s":
+			if op == Merge {
+				ui.Error("Invalid option, please try again")
+				continue
+			}
+			BeforeChange(string(op) + " --skip")
+			if err := system.RunGit(string(op), "--skip"); err != nil && !Pending() {
+				ui.Error("Skip failed (see error.log)")
+				continue
+			}
+			if InProgress() == None {
+				ui.Success(op.title() + " completed")
+				return true
+			}
+		case "q":
+			ui.Warn("Conflicts left unresolved — reopen the assistant to continue")
+			return false
+		default:
+			n, err := strconv.Atoi(choice)
+			if err != nil || n < 1 || n > len(files) {
+				ui.Error("Invalid option, please try again")
+				continue
+			}
+			resolveFile(files[n-1])
+		}
+	}
+}
+
+/*
+resolveLoose handles conflicts left by commands that do not stay in
+progress (stash apply/pop, checkout -m): there is nothing to continue
+or abort, so files are resolved, re-conflicted or reset to HEAD.
+*/
+func resolveLoose() bool {
+	for {
+		files := conflictedFiles()
+		if len(files) == 0 {
+			ui.Success("All conflicts resolved")
+			return true
+		}
+
+		fmt.Println()
+		ui.Header("Conflict Assistant — no operation in progress")
+		ui.Info("Conflicts from stash apply/pop or checkout -m (a conflicting pop keeps the stash)")
+		fmt.Println("Conflicted files:")
+		for i, f := range files {
+			fmt.Printf("%2d) %s %s\n", i+1, f.Path, ui.Yellow+"("+describe(f.Status)+")"+ui.Reset)
+		}
+
+		fmt.Println()
+		fmt.Println(" m) Mark all as resolved")
+		fmt.Println(" r) Re-create conflict markers (checkout --merge)")
+		fmt.Println(" x) Reset conflicted files to HEAD (discard both sides' edits)")
+		fmt.Println(" q) Leave for later")
+
+		choice := ui.Input("Select file number or action")
+		switch choice {
+		case "m":
+			for _, f := range files {
+				markResolved(f)
+			}
+		case "r":
+			if !ui.Confirm("This discards manual edits in the conflicted files. Continue?") {
+				continue
+			}
+			args := []string{"checkout", "--merge", "--"}
+			for _, f := range files {
+				args = append(args, top(f.Path))
+			}
+			if err := system.RunGit(args...); err != nil {
+				ui.Error("Failed to re-create conflict markers")
+			}
+		case "x":
+			if !ui.Confirm("Reset " + strconv.Itoa(len(files)) + " file(s) to HEAD? Their changes are lost") {
+				continue
+			}
+			for _, f := range files {
+				resetToHead(f)
+			}
+		case "q":
+			ui.Warn("Conflicts left unresolved — reopen the assistant to continue")
+			return false
+		default:
+			n, err := strconv.Atoi(choice)
+			if err != nil || n < 1 || n > len(files) {
+				ui.Error("Invalid option, please try again")
+				continue
+			}
+			resolveFile(files[n-1])
+		}
+	}
+}
+
+// resetToHead restores a file from HEAD, or removes it when HEAD has none
+func resetToHead(f file) {
+	var err error
+	if _, inHead := system.GitQuery("cat-file", "-e", "HEAD:"+f.Path); inHead {
+		err = system.RunGit("checkout", "HEAD", "--", top(f.Path))
+	} else {
+		err = system.RunGit("rm", "--quiet", "--force", "--", top(f.Path))
+	}
+	if err != nil {
+		ui.Error("Failed to reset " + f.Path)
+		return
+	}
+	ui.Success("Reset " + f.Path)
+}
+
+// cont continues the operation without opening an editor
+func cont(op Operation) bool {
+	var err error
+	switch op {
+	case Merge:
+		err = system.RunGit("commit", "--no-edit")
+	default:
+		err = system.RunGit("-c", "core.editor=true", string(op), "--continue")
+	}
+
+	if err != nil {
+		if len(conflictedFiles()) > 0 {
+			ui.Warn("New conflicts appeared while continuing")
+		} else {
+			ui.Error("Continue failed (see error.log)")
+		}
+		return false
+	}
+	return true
+}
+
+// title capitalises the operation name for messages
+func (op Operation) title() string {
+	if op == None {
+		return ""
+	}
+	return strings.ToUpper(string(op[:1])) + string(op[1:])
+}
+
+/* ============================================================
+   Per-file actions
+   ============================================================ */
+
+func resolveFile(f file) {
+	for {
+		fmt.Println()
+		ui.Header(f.Path)
+		fmt.Println("1) Show conflict hunks")
+		fmt.Println("2) Resolve hunk by hunk")
+		fmt.Println("3) Take ours for whole file")
+		fmt.Println("4) Take theirs for whole file")
+		fmt.Println("5) Open in editor")
+		fmt.Println("6) Mark as resolved")
+		fmt.Println("7) Re-create markers with base (diff3)")
+		fmt.Println("0) Back")
+
+		switch ui.Input("Select option") {
+		case "1":
+			showHunks(f)
+		case "2":
+			if resolveHunks(f) {
+				markResolved(f)
+				return
+			}
+		case "3":
+			if takeSide(f, "--ours") {
+				return
+			}
+		case "4":
+			if takeSide(f, "--theirs") {
+				return
+			}
+		case "5":
+			if err := system.OpenEditor(abs(f.Path)); err != nil {
+				ui.Error("Could not open editor: " + err.Error())
+			}
+		case "6":
+			if markResolved(f) {
+				return
+			}
+		case "7":
+			if ui.Confirm("This discards manual edits in " + f.Path + ". Continue?") {
+				if err := system.RunGit("checkout", "--conflict=diff3", "--", top(f.Path)); err != nil {
+					ui.Error("Failed to re-create conflict markers")
+				}
+			}
+		case "0":
+			return
+		default:
+			ui.Error("Invalid option, please try again")
+		}
+	}
+}
+
+func showHunks(f file) {
+	data, err := os.ReadFile(abs(f.Path))
+	if err != nil {
+		ui.Error("Cannot read " + f.Path)
+		return
+	}
+
+	hs := hunks(parse(string(data)))
+	if len(hs) == 0 {
+		ui.Info("No conflict markers in " + f.Path)
+		return
+	}
+
+	for i, h := range hs {
+		printHunk(i+1, len(hs), h)
+	}
+}
+
+func printHunk(n, total int, h *Hunk) {
+	fmt.Printf("\n%s── Hunk %d/%d ──%s\n", ui.Bold, n, total, ui.Reset)
+	printSide("ours ("+h.OursLabel+")", ui.Green, h.Ours)
+	if h.HasBase {
+		printSide("base", ui.Blue, h.Base)
+	}
+	printSide("theirs ("+h.TheirsLabel+")", ui.Red, h.Theirs)
+}
+
+func printSide(title, color string, lines []string) {
+	fmt.Println(color + "▌ " + title + ui.Reset)
+	if len(lines) == 0 {
+		fmt.Println(color + "│ (empty)" + ui.Reset)
+		return
+	}
+	for _, l := range lines {
+		fmt.Println(color + "│ " + ui.Reset + strings.TrimRight(l, "\r\n"))
+	}
+}
+
+// resolveHunks walks through each hunk and asks which side to keep.
+// It returns true when the file no longer contains conflict markers.
+func resolveHunks(f file) bool {
+	path := abs(f.Path)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		ui.Error("Cannot read " + f.Path)
+		return false
+	}
+
+	segs := parse(string(data))
+	hs := hunks(segs)
+	if len(hs) == 0 {
+		ui.Info("No conflict markers in " + f.Path)
+		return false
+	}
+
+	choices := map[*Hunk]string{}
+	for i, h := range hs {
+		printHunk(i+1, len(hs), h)
+
+		prompt := "Keep o) ours  t) theirs  b) both  s) skip"
+		if h.HasBase {
+			prompt = "Keep o) ours  t) theirs  b) both  B) base  s) skip"
+		}
+
+		for {
+			c := ui.Input(prompt)
+			if c == TakeOurs || c == TakeTheirs || c == TakeBoth || c == Skip ||
+				(c == TakeBase && h.HasBase) {
+				choices[h] = c
+				break
+			}
+			ui.Error("Invalid choice")
+		}
+	}
+
+	// Keep the file's mode (e.g. executable scripts)
+	mode := os.FileMode(0644)
+	if st, err := os.Stat(path); err == nil {
+		mode = st.Mode().Perm()
+	}
+
+	out := render(segs, choices)
+	if err := os.WriteFile(path, []byte(out), mode); err != nil {
+		system.LogError("writing resolved file", err)
+		ui.Error("Failed to write " + f.Path)
+		return false
+	}
+
+	if hasMarkers(out) {
+		ui.Warn("Some hunks were skipped — " + f.Path + " still has conflicts")
+		return false
+	}
+
+	ui.Success("All hunks resolved in " + f.Path)
+	return true
+}
+
+func takeSide(f file, side string) bool {
+	if err := system.RunGit("checkout", side, "--", top(f.Path)); err != nil {
+		// The chosen side deleted the file
+		if ui.Confirm("That side has no version of " + f.Path + ". Delete the file?") {
+			if err := system.RunGit("rm", "--quiet", "--", top(f.Path)); err == nil {
+				ui.Success("Resolved by deleting " + f.Path)
+				return true
+			}
+		}
+		ui.Error("Failed to take " + strings.TrimPrefix(side, "--") + " version")
+		return false
+	}
+	return markResolved(f)
+}
+
+func markResolved(f file) bool {
+	if data, err := os.ReadFile(abs(f.Path)); err == nil && hasMarkers(string(data)) {
+		if !ui.Confirm(f.Path + " still contains conflict markers. Mark resolved anyway?") {
+			return false
+		}
+	}
+
+	if err := system.RunGit("add", "--", top(f.Path)); err != nil {
+		ui.Error("Failed to mark " + f.Path + " as resolved")
+		return false
+	}
+
+	ui.Success("Marked resolved: " + f.Path)
+	return true
+}
+
+/* ============================================================
+   Helpers
+   ============================================================ */
+
+func describe(xy string) string {
+	switch xy {
+	case "UU":
+		return "both modified"
+	case "AA":
+		return "both added"
+	case "DD":
+		return "both deleted"
+	case "AU":
+		return "added by us"
+	case "UA":
+		return "added by them"
+	case "DU":
+		return "deleted by us"
+	case "UD":
+		return "deleted by them"
+	}
+	return xy
+}
+
+func gitPathExists(name string) bool {
+	p := system.GitPath(name)
+	if p == "" {
+		return false
+	}
+	_, err := os.Stat(p)
+	return err == nil
+}
+
+// abs turns a repository-relative path into a filesystem path
+func abs(p string) string {
+	return filepath.Join(system.RepoRoot(), p)
+}
+
+// top turns a repository-relative path into a pathspec valid from any WorkDir
+func top(p string) string {
+	return ":(top)" + p
+}
+
+// unquote strips the quotes git adds around unusual paths
+func unquote(p string) string {
+	if s, err := strconv.Unquote(p); err == nil {
+		return s
+	}
+	return p
+}
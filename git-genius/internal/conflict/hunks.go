@@ -0,0 +1,165 @@
+package conflict
+
+import (
+	"strings"
+)
+
+// Hunk is one conflicted region between <<<<<<< and >>>>>>> markers
+type Hunk struct {
+	OursLabel   string
+	BaseLabel   string
+	TheirsLabel string
+
+	Ours    []string
+	Base    []string // only present with diff3 / zdiff3 markers
+	Theirs  []string
+	HasBase bool
+}
+
+// segment is either plain text or a conflict hunk
+type segment struct {
+	lines []string
+	hunk  *Hunk
+}
+
+// Resolution choices for a single hunk
+const (
+	TakeOurs   = "o"
+	TakeTheirs = "t"
+	TakeBoth   = "b"
+	TakeBase   = "B"
+	Skip       = "s"
+)
+
+/* ============================================================
+   Parsing
+   ============================================================ */
+
+// parse splits file content into plain segments and conflict hunks.
+// Unterminated markers are kept as plain text.
+func parse(content string) []segment {
+	lines := strings.SplitAfter(content, "\n")
+	if n := len(lines); n > 0 && lines[n-1] == "" {
+		lines = lines[:n-1]
+	}
+
+	var segs []segment
+	var plain []string
+
+	flush := func() {
+		if len(plain) > 0 {
+			segs = append(segs, segment{lines: plain})
+			plain = nil
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		if !strings.HasPrefix(lines[i], "<<<<<<<") {
+			plain = append(plain, lines[i])
+			continue
+		}
+
+		h, end := parseHunk(lines, i)
+		if h == nil {
+			plain = append(plain, lines[i])
+			continue
+		}
+
+		flush()
+		segs = append(segs, segment{hunk: h})
+		i = end
+	}
+
+	flush()
+	return segs
+}
+
+// parseHunk reads one hunk starting at lines[start] and returns it with
+// the index of its closing marker, or nil when the hunk is malformed.
+func parseHunk(lines []string, start int) (*Hunk, int) {
+	h := &Hunk{OursLabel: markerLabel(lines[start])}
+	section := &h.Ours
+
+	for i := start + 1; i < len(lines); i++ {
+		l := lines[i]
+		switch {
+		case strings.HasPrefix(l, "|||||||") && section == &h.Ours:
+			h.HasBase = true
+			h.BaseLabel = markerLabel(l)
+			section = &h.Base
+		case strings.HasPrefix(l, "=======") && section != &h.Theirs:
+			section = &h.Theirs
+		case strings.HasPrefix(l, ">>>>>>>") && section == &h.Theirs:
+			h.TheirsLabel = markerLabel(l)
+			return h, i
+		case strings.HasPrefix(l, "<<<<<<<"):
+			return nil, start
+		default:
+			*section = append(*section, l)
+		}
+	}
+
+	return nil, start
+}
+
+func markerLabel(line string) string {
+	return strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "<>|"))
+}
+
+/* ============================================================
+   Rendering
+   ============================================================ */
+
+// hunks returns the conflict hunks of the parsed file
+func hunks(segs []segment) []*Hunk {
+	var out []*Hunk
+	for _, s := range segs {
+		if s.hunk != nil {
+			out = append(out, s.hunk)
+		}
+	}
+	return out
+}
+
+// render rebuilds the file, replacing each hunk with its chosen side.
+// Hunks without a choice (or marked Skip) keep their markers.
+func render(segs []segment, choices map[*Hunk]string) string {
+	var b strings.Builder
+
+	for _, s := range segs {
+		if s.hunk == nil {
+			b.WriteString(strings.Join(s.lines, ""))
+			continue
+		}
+
+		h := s.hunk
+		switch choices[h] {
+		case TakeOurs:
+			b.WriteString(strings.Join(h.Ours, ""))
+		case TakeTheirs:
+			b.WriteString(strings.Join(h.Theirs, ""))
+		case TakeBoth:
+			b.WriteString(strings.Join(h.Ours, ""))
+			b.WriteString(strings.Join(h.Theirs, ""))
+		case TakeBase:
+			b.WriteString(strings.Join(h.Base, ""))
+		default:
+			b.WriteString("<<<<<<< " + h.OursLabel + "\n")
+			b.WriteString(strings.Join(h.Ours, ""))
+			if h.HasBase {
+				b.WriteString("||||||| " + h.BaseLabel + "\n")
+				b.WriteString(strings.Join(h.Base, ""))
+			}
+			b.WriteString("=======\n")
+			b.WriteString(strings.Join(h.Theirs, ""))
+			b.WriteString(">>>>>>> " + h.TheirsLabel + "\n")
+		}
+	}
+
+	return b.String()
+}
+
+// hasMarkers reports whether content still contains conflict markers
+func hasMarkers(content string) bool {
+	return len(hunks(parse(content))) > 0
+}
@@ -0,0 +1,182 @@
+package diff
+
+import (
+	"strconv"
+	"strings"
+)
+
+// File is the diff of a single path
+type File struct {
+	OldPath string
+	NewPath string
+	Extra   []string // extended headers: mode changes, renames, index ...
+	Binary  bool
+	Hunks   []Hunk
+}
+
+// Hunk is one @@ section of a file diff
+type Hunk struct {
+	Header   string // the full "@@ -a,b +c,d @@ context" line
+	OldStart int
+	NewStart int
+	Lines    []Line
+}
+
+// Line is a single diff line
+type Line struct {
+	Kind byte // '+', '-', ' ' or '\\' (no newline at end of file)
+	Text string
+}
+
+// Name returns the path shown for the file (new path unless deleted)
+func (f File) Name() string {
+	if f.NewPath == "" || f.NewPath == "/dev/null" {
+		return f.OldPath
+	}
+	return f.NewPath
+}
+
+// Stats counts added and removed lines
+func (f File) Stats() (added, removed int) {
+	for _, h := range f.Hunks {
+		for _, l := range h.Lines {
+			switch l.Kind {
+			case '+':
+				added++
+			case '-':
+				removed++
+			}
+		}
+	}
+	return
+}
+
+/* ============================================================
+   Parsing
+   ============================================================ */
+
+/*
+Parse reads unified diff output as printed by "git diff", "git show" or
+"git stash show -p" (without color). Text outside "diff --git" sections,
+such as commit headers, is ignored.
+*/
+func Parse(out string) []File {
+	var files []File
+	var f *File
+	var h *Hunk
+
+	for _, l := range strings.Split(out, "\n") {
+		switch {
+		case strings.HasPrefix(l, "diff --git "):
+			files = append(files, File{})
+			f = &files[len(files)-1]
+			h = nil
+			f.OldPath, f.NewPath = splitGitPaths(strings.TrimPrefix(l, "diff --git "))
+
+		case f == nil:
+			continue
+
+		case h == nil && strings.HasPrefix(l, "--- "):
+			f.OldPath = trimPath(strings.TrimPrefix(l, "--- "))
+
+		case h == nil && strings.HasPrefix(l, "+++ "):
+			f.NewPath = trimPath(strings.TrimPrefix(l, "+++ "))
+
+		case strings.HasPrefix(l, "@@"):
+			f.Hunks = append(f.Hunks, parseHunkHeader(l))
+			h = &f.Hunks[len(f.Hunks)-1]
+
+		case h == nil:
+			if strings.HasPrefix(l, "Binary files") || strings.HasPrefix(l, "GIT binary patch") {
+				f.Binary = true
+			}
+			f.Extra = append(f.Extra, l)
+
+		case l == "":
+			// trailing newline of the output; real blank context lines start with ' '
+
+		default:
+			h.Lines = append(h.Lines, Line{Kind: l[0], Text: l[1:]})
+		}
+	}
+
+	return files
+}
+
+// splitGitPaths splits "a/x b/x" from a "diff --git" line; either side
+// may be C-quoted ("a/caf\303\251 x") when it has unusual characters
+func splitGitPaths(s string) (string, string) {
+	if strings.HasPrefix(s, "\"") {
+		if end := closingQuote(s); end > 0 {
+			return trimPath(s[:end+1]), trimPath(s[end+1:])
+		}
+	}
+	if i := strings.Index(s, " \"b/"); i >= 0 {
+		return trimPath(s[:i]), trimPath(s[i+1:])
+	}
+	if i := strings.Index(s, " b/"); i >= 0 {
+		return trimPath(s[:i]), trimPath(s[i+1:])
+	}
+	return trimPath(s), trimPath(s)
+}
+
+// closingQuote returns the index of the quote ending the string s opens
+func closingQuote(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+func trimPath(p string) string {
+	p = strings.TrimSpace(p)
+	if p == "/dev/null" {
+		return p
+	}
+	if strings.HasPrefix(p, "\"") {
+		// git escapes like Go: \" \\ \t and octal bytes (\303\251)
+		if u, err := strconv.Unquote(p); err == nil {
+			p = u
+		} else {
+			p = strings.Trim(p, "\"")
+		}
+	}
+	if strings.HasPrefix(p, "a/") || strings.HasPrefix(p, "b/") {
+		return p[2:]
+	}
+	return p
+}
+
+// parseHunkHeader reads "@@ -12,7 +12,8 @@ func x()"
+func parseHunkHeader(l string) Hunk {
+	h := Hunk{Header: l}
+	fields := strings.Fields(l)
+	for _, f := range fields[1:] {
+		switch {
+		case strings.HasPrefix(f, "-"):
+			h.OldStart = atoi(strings.SplitN(f[1:], ",", 2)[0])
+		case strings.HasPrefix(f, "+"):
+			h.NewStart = atoi(strings.SplitN(f[1:], ",", 2)[0])
+		}
+		if f == "@@" {
+			break
+		}
+	}
+	return h
+}
+
+func atoi(s string) int {
+	n := 0
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			break
+		}
+		n = n*10 + int(r-'0')
+	}
+	return n
+}
@@ -0,0 +1,182 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"git-genius/internal/ui"
+)
+
+// Intra-line highlights: inverse colors on top of the line color
+const (
+	delWord = "\033[7;31m"
+	addWord = "\033[7;32m"
+)
+
+// maxWordCells bounds the word-diff table per line pair
+const maxWordCells = 40000
+
+/*
+Render turns parsed files into colored lines ready for paging.
+Removed lines followed by added lines are paired and their changed
+words highlighted.
+*/
+func Render(files []File) []string {
+	var out []string
+
+	for _, f := range files {
+		added, removed := f.Stats()
+		out = append(out, fmt.Sprintf("%s━━ %s %s+%d%s %s-%d%s",
+			ui.Bold, f.Name(), ui.Green, added, ui.Reset, ui.Red, removed, ui.Reset))
+
+		if f.OldPath != f.NewPath && f.OldPath != "/dev/null" && f.NewPath != "/dev/null" {
+			out = append(out, ui.Cyan+"renamed: "+f.OldPath+" → "+f.NewPath+ui.Reset)
+		}
+		if f.Binary {
+			out = append(out, ui.Yellow+"(binary file)"+ui.Reset)
+		}
+
+		for _, h := range f.Hunks {
+			out = append(out, ui.Cyan+h.Header+ui.Reset)
+			out = append(out, renderHunk(h)...)
+		}
+		out = append(out, "")
+	}
+
+	return out
+}
+
+func renderHunk(h Hunk) []string {
+	var out []string
+	lines := h.Lines
+
+	for i := 0; i < len(lines); {
+		if lines[i].Kind != '-' {
+			out = append(out, plainLine(lines[i]))
+			i++
+			continue
+		}
+
+		// Collect a block of removals and the additions right after it
+		j := i
+		for j < len(lines) && lines[j].Kind == '-' {
+			j++
+		}
+		k := j
+		for k < len(lines) && lines[k].Kind == '+' {
+			k++
+		}
+
+		dels, adds := lines[i:j], lines[j:k]
+		var delOut, addOut []string
+		for n := range dels {
+			if n < len(adds) {
+				d, a := wordDiff(dels[n].Text, adds[n].Text)
+				delOut = append(delOut, ui.Red+"-"+d+ui.Reset)
+				addOut = append(addOut, ui.Green+"+"+a+ui.Reset)
+			} else {
+				delOut = append(delOut, plainLine(dels[n]))
+			}
+		}
+		for n := len(dels); n < len(adds); n++ {
+			addOut = append(addOut, plainLine(adds[n]))
+		}
+
+		out = append(out, delOut...)
+		out = append(out, addOut...)
+		i = k
+	}
+
+	return out
+}
+
+func plainLine(l Line) string {
+	switch l.Kind {
+	case '+':
+		return ui.Green + "+" + l.Text + ui.Reset
+	case '-':
+		return ui.Red + "-" + l.Text + ui.Reset
+	case '\\':
+		return ui.Yellow + "\\" + l.Text + ui.Reset
+	}
+	return " " + l.Text
+}
+
+/* ============================================================
+   Word diff
+   ============================================================ */
+
+// wordDiff highlights the tokens that differ between old and new
+func wordDiff(oldText, newText string) (string, string) {
+	a, b := tokenize(oldText), tokenize(newText)
+	if len(a)*len(b) > maxWordCells {
+		return oldText, newText
+	}
+
+	// LCS table over tokens
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var ob, nb strings.Builder
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			ob.WriteString(a[i])
+			nb.WriteString(b[j])
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lcs[i][j+1] >= lcs[i+1][j]):
+			nb.WriteString(addWord + b[j] + ui.Reset + ui.Green)
+			j++
+		default:
+			ob.WriteString(delWord + a[i] + ui.Reset + ui.Red)
+			i++
+		}
+	}
+
+	return ob.String(), nb.String()
+}
+
+// tokenize splits text into words, whitespace runs and single symbols
+func tokenize(s string) []string {
+	var toks []string
+	rs := []rune(s)
+
+	class := func(r rune) int {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_':
+			return 1
+		case unicode.IsSpace(r):
+			return 2
+		}
+		return 0
+	}
+
+	for i := 0; i < len(rs); {
+		c := class(rs[i])
+		j := i + 1
+		if c != 0 {
+			for j < len(rs) && class(rs[j]) == c {
+				j++
+			}
+		}
+		toks = append(toks, string(rs[i:j]))
+		i = j
+	}
+	return toks
+}
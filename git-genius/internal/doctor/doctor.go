@@ -1,6 +1,8 @@
 package doctor
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -8,6 +10,7 @@ import (
 
 	"git-genius/internal/config"
 	"git-genius/internal/github"
+	"git-genius/internal/gitops"
 	"git-genius/internal/system"
 	"git-genius/internal/ui"
 )
@@ -22,6 +25,10 @@ func Run() {
 	checkGitConfig()
 	checkInternet()
 	checkGitHubToken()
+	checkSSH()
+	checkSigning()
+	checkLargeFiles()
+	checkLFS()
 	checkErrorLog()
 
 	ui.Success("Doctor check completed")
@@ -84,6 +91,11 @@ func checkGitConfig() {
 	cfg := config.Load()
 	ui.Info("Default branch : " + cfg.Branch)
 	ui.Info("Default remote : " + cfg.Remote)
+	if len(cfg.PushRemotes) > 0 {
+		ui.Info("Push mirrors   : " + strings.Join(cfg.PushRemotes, ", "))
+	}
+	ui.Info("Pull strategy  : " + cfg.PullStrategy)
+	ui.Info("Commit style   : " + cfg.CommitStyle)
 
 	if cfg.Owner != "" && cfg.Repo != "" {
 		ui.Info("GitHub repo     : https://github.com/" + cfg.Owner + "/" + cfg.Repo)
@@ -101,7 +113,11 @@ func checkInternet() {
 func checkGitHubToken() {
 	token := github.Get()
 	if token == "" {
-		ui.Warn("GitHub token not configured")
+		if config.Load().Auth == "ssh" {
+			ui.Info("GitHub token not configured (not required for SSH)")
+		} else {
+			ui.Warn("GitHub token not configured")
+		}
 		return
 	}
 
@@ -119,6 +135,148 @@ func checkGitHubToken() {
 	ui.Success("GitHub authenticated as: " + user)
 }
 
+func checkSSH() {
+	cfg := config.Load()
+	if cfg.Auth != "ssh" {
+		return
+	}
+
+	sshCmd := gitConfig("core.sshCommand")
+	if sshCmd == "" {
+		sshCmd = "ssh"
+	}
+	client := strings.Trim(strings.Fields(sshCmd)[0], `"'`)
+
+	if _, err := exec.LookPath(client); err != nil {
+		ui.Error("SSH client not installed (" + client + ")")
+		return
+	}
+
+	if !system.Online {
+		ui.Warn("SSH connectivity check skipped (offline)")
+		return
+	}
+
+	// Run through the shell like git does, so quoted key paths work.
+	// GitHub answers "successfully authenticated" and exits with 1
+	args := []string{"-c", sshCmd + ` "$@"`, "sh",
+		"-T", "-o", "BatchMode=yes", "-o", "StrictHostKeyChecking=accept-new",
+		"-o", "ConnectTimeout=10", "git@github.com",
+	}
+	out, _ := exec.Command("sh", args...).CombinedOutput()
+	msg := strings.TrimSpace(string(out))
+
+	if strings.Contains(msg, "successfully authenticated") {
+		ui.Success("SSH authentication to GitHub works")
+		return
+	}
+
+	system.LogError("ssh connectivity check", errors.New(msg))
+	ui.Error("SSH authentication to GitHub failed (see error.log)")
+}
+
+func checkSigning() {
+	cfg := config.Load()
+	if cfg.Signing == "" {
+		ui.Info("Commit signing : off")
+		return
+	}
+
+	key := cfg.SigningKey
+	if key == "" {
+		key = gitConfig("user.signingkey")
+	}
+	ui.Info("Commit signing : " + cfg.Signing + " (" + key + ")")
+
+	if err := system.VerifySigning(); err != nil {
+		system.LogError("signing test", err)
+		ui.Error("Signing does not work: " + err.Error())
+		return
+	}
+	ui.Success("Test signature created")
+
+	if key == "" {
+		ui.Warn("No signing key configured — cannot check GitHub registration")
+		return
+	}
+
+	// GitHub compares the public key line for SSH signing
+	if cfg.Signing == "ssh" {
+		data, err := os.ReadFile(key)
+		if err != nil {
+			ui.Error("Cannot read signing key " + key)
+			return
+		}
+		key = string(data)
+	}
+
+	if github.Get() == "" {
+		ui.Info("Signing key registration not checked (no GitHub token)")
+		return
+	}
+	if !system.Online {
+		ui.Warn("Signing key registration check skipped (offline)")
+		return
+	}
+
+	ok, err := github.SigningKeyRegistered(cfg.Signing, key)
+	switch {
+	case err != nil:
+		ui.Warn("Could not check signing key on GitHub: " + err.Error())
+	case ok:
+		ui.Success("Signing key is registered on GitHub (commits show as Verified)")
+	default:
+		ui.Error("Signing key is not registered on GitHub — commits will show as Unverified")
+	}
+}
+
+func checkLargeFiles() {
+	if !system.IsGitRepo() {
+		return
+	}
+
+	limit := gitops.MaxFileBytes(config.Load())
+	if limit < 0 {
+		ui.Info("Large file guard : off")
+		limit = 10 << 20
+	} else {
+		ui.Info("Large file guard : " + gitops.HumanSize(limit))
+	}
+
+	blobs, err := gitops.LargeBlobs(limit, 5)
+	if err != nil {
+		ui.Warn("Could not scan history for large files (see error.log)")
+		return
+	}
+	if len(blobs) == 0 {
+		ui.Success("No files larger than " + gitops.HumanSize(limit) + " in history")
+		return
+	}
+
+	ui.Warn("Largest files in history (they bloat every clone):")
+	for _, b := range blobs {
+		fmt.Printf("  %-10s %s\n", gitops.HumanSize(b.Size), b.Path)
+	}
+}
+
+func checkLFS() {
+	installed := gitops.LFSInstalled()
+	patterns := gitops.LFSPatterns()
+
+	switch {
+	case len(patterns) > 0 && !installed:
+		ui.Error("Files are tracked with Git LFS but git-lfs is not installed")
+	case len(patterns) > 0 && gitConfig("filter.lfs.clean") == "":
+		ui.Warn("Git LFS is installed but not set up here — run: git lfs install")
+	case len(patterns) > 0:
+		ui.Success("Git LFS tracking: " + strings.Join(patterns, ", "))
+	case installed:
+		ui.Info("Git LFS installed (not used in this repository)")
+	default:
+		ui.Info("Git LFS not installed")
+	}
+}
+
 func checkErrorLog() {
 	cfg := config.Load()
 
@@ -0,0 +1,73 @@
+package github
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"git-genius/internal/system"
+)
+
+// ErrKeyExists is returned when GitHub already knows the key
+var ErrKeyExists = errors.New("key is already registered on GitHub")
+
+type sshKeyRequest struct {
+	Title string `json:"title"`
+	Key   string `json:"key"`
+}
+
+// -------------------- SSH KEYS --------------------
+
+/*
+AddSSHKey registers a public key for SSH authentication on the account
+of the stored token. The token needs the "admin:public_key" scope.
+*/
+func AddSSHKey(title, publicKey string) error {
+	return addKey("/user/keys", "admin:public_key", title, publicKey)
+}
+
+/*
+AddSSHSigningKey registers a public key for commit signature
+verification. The token needs the "admin:ssh_signing_key" scope.
+*/
+func AddSSHSigningKey(title, publicKey string) error {
+	return addKey("/user/ssh_signing_keys", "admin:ssh_signing_key", title, publicKey)
+}
+
+func addKey(path, scope, title, publicKey string) error {
+	token := Get()
+	if token == "" {
+		return errors.New("no GitHub token found")
+	}
+	if !system.Online {
+		return errors.New("offline: cannot reach GitHub")
+	}
+
+	// GitHub rejects the comment part of an authorized_keys line
+	fields := strings.Fields(publicKey)
+	if len(fields) < 2 {
+		return errors.New("malformed public key")
+	}
+
+	body, _ := json.Marshal(sshKeyRequest{Title: title, Key: fields[0] + " " + fields[1]})
+
+	resp, err := call("POST", apiBase+path, token, bytes.NewReader(body))
+	if err != nil {
+		system.LogError("github add key failed", err)
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusCreated:
+		return nil
+	case http.StatusUnprocessableEntity:
+		return ErrKeyExists
+	case http.StatusNotFound, http.StatusForbidden, http.StatusUnauthorized:
+		return errors.New("token lacks the " + scope + " scope")
+	}
+	return fmt.Errorf("github returned %s", resp.Status)
+}
@@ -0,0 +1,108 @@
+package github
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"git-genius/internal/system"
+)
+
+type gpgKey struct {
+	KeyID   string   `json:"key_id"`
+	Subkeys []gpgKey `json:"subkeys"`
+}
+
+type sshSigningKey struct {
+	Key string `json:"key"`
+}
+
+// -------------------- SIGNING KEYS --------------------
+
+/*
+SigningKeyRegistered reports whether the signing key is known to GitHub,
+so commits signed with it show as "Verified". format is "gpg" (key is a
+key id or fingerprint) or "ssh" (key is the public key line).
+The token needs the read:gpg_key or read:ssh_signing_key scope.
+*/
+func SigningKeyRegistered(format, key string) (bool, error) {
+	token := Get()
+	if token == "" {
+		return false, errors.New("no GitHub token found")
+	}
+	if !system.Online {
+		return false, errors.New("offline: cannot reach GitHub")
+	}
+
+	path, scope := "/user/gpg_keys", "read:gpg_key"
+	if format == "ssh" {
+		path, scope = "/user/ssh_signing_keys", "read:ssh_signing_key"
+	}
+
+	resp, err := call("GET", apiBase+path, token, nil)
+	if err != nil {
+		system.LogError("github signing keys request failed", err)
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusForbidden, http.StatusUnauthorized:
+		return false, errors.New("token lacks the " + scope + " scope")
+	default:
+		return false, fmt.Errorf("github returned %s", resp.Status)
+	}
+
+	if format == "ssh" {
+		var keys []sshSigningKey
+		if err := json.NewDecoder(resp.Body).Decode(&keys); err != nil {
+			return false, err
+		}
+		want := keyData(key)
+		for _, k := range keys {
+			if want != "" && keyData(k.Key) == want {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	var keys []gpgKey
+	if err := json.NewDecoder(resp.Body).Decode(&keys); err != nil {
+		return false, err
+	}
+	for _, k := range keys {
+		if gpgMatch(k, key) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// keyData returns the base64 part of an SSH public key line
+func keyData(line string) string {
+	f := strings.Fields(line)
+	if len(f) < 2 {
+		return ""
+	}
+	return f[1]
+}
+
+// gpgMatch compares a GitHub key (or subkey) with a local id/fingerprint;
+// GitHub reports 16-digit long ids
+func gpgMatch(k gpgKey, id string) bool {
+	id = strings.ToUpper(strings.TrimSuffix(strings.TrimPrefix(id, "0x"), "!"))
+	got := strings.ToUpper(k.KeyID)
+	if got != "" && len(id) >= 8 && (strings.HasSuffix(id, got) || strings.HasSuffix(got, id)) {
+		return true
+	}
+	for _, s := range k.Subkeys {
+		if gpgMatch(s, id) {
+			return true
+		}
+	}
+	return false
+}
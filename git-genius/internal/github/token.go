@@ -3,6 +3,7 @@ package github
 import (
 	"encoding/json"
 	"errors"
+	"io"
 	"net/http"
 	"os"
 	"time"
@@ -13,7 +14,8 @@ import (
 const (
 	geniusDir = ".git/.genius"
 	tokenFile = geniusDir + "/token"
-	apiURL    = "https://api.github.com/user"
+	apiBase   = "https://api.github.com"
+	apiURL    = apiBase + "/user"
 )
 
 type userResponse struct {
@@ -42,6 +44,26 @@ func Delete() {
 	_ = os.Remove(tokenFile)
 }
 
+// -------------------- API --------------------
+
+// call performs an authenticated GitHub API request
+func call(method, url, token string, body io.Reader) (*http.Response, error) {
+	client := http.Client{Timeout: 10 * time.Second}
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", "token "+token)
+	req.Header.Set("User-Agent", "git-genius")
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return client.Do(req)
+}
+
 // -------------------- VALIDATION --------------------
 
 // Validate checks token validity using GitHub API
@@ -57,16 +79,7 @@ func Validate() (string, error) {
 		return "offline-mode", nil
 	}
 
-	client := http.Client{Timeout: 5 * time.Second}
-	req, err := http.NewRequest("GET", apiURL, nil)
-	if err != nil {
-		return "", err
-	}
-
-	req.Header.Set("Authorization", "token "+token)
-	req.Header.Set("User-Agent", "git-genius")
-
-	resp, err := client.Do(req)
+	resp, err := call("GET", apiURL, token, nil)
 	if err != nil {
 		system.LogError("github api request failed", err)
 		return "", err
@@ -0,0 +1,104 @@
+package gitignore
+
+import (
+	"fmt"
+	"strings"
+
+	"git-genius/internal/system"
+	"git-genius/internal/ui"
+)
+
+// TrackedIgnored lists tracked files that the ignore rules now exclude
+func TrackedIgnored() []string {
+	out, ok := system.GitQuery("ls-files", "-ci", "--exclude-standard", "-z")
+	if !ok || out == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimRight(out, "\x00"), "\x00")
+}
+
+// Untrack removes paths from the index; the files stay on disk
+func Untrack(paths []string) error {
+	args := append([]string{"--literal-pathspecs", "rm", "--cached", "-q", "--"}, paths...)
+	return system.RunGit(args...)
+}
+
+/* ============================================================
+   Interactive
+   ============================================================ */
+
+// Run detects the project type, merges templates into .gitignore and
+// offers to untrack files that are ignored now
+func Run() {
+	ui.Header(".gitignore Generator")
+
+	if !system.EnsureGitRepo() {
+		return
+	}
+	root := system.RepoRoot()
+
+	detected := Detect(system.WorkDir())
+	names := make([]string, len(detected))
+	for i, t := range detected {
+		names[i] = t.Name
+	}
+	ui.Info("Detected: " + strings.Join(names, ", "))
+
+	tpls := detected
+	if !ui.Confirm("Use the detected templates?") {
+		items := make([]string, len(Templates))
+		for i, t := range Templates {
+			items[i] = t.Name
+		}
+		tpls = nil
+		for _, i := range ui.MultiSelect("Templates", items) {
+			tpls = append(tpls, Templates[i])
+		}
+		if len(tpls) == 0 {
+			ui.Warn("No templates selected")
+			return
+		}
+	}
+
+	add := Merge(Read(root), tpls)
+	if add == "" {
+		ui.Success(".gitignore already covers these templates")
+	} else {
+		ui.Page(strings.Split(strings.TrimLeft(add, "\n"), "\n"))
+		if !ui.Confirm("Add these rules to .gitignore?") {
+			return
+		}
+		if err := Append(root, add); err != nil {
+			system.LogError("write .gitignore", err)
+			ui.Error("Could not write .gitignore: " + err.Error())
+			return
+		}
+		ui.Success(".gitignore updated")
+	}
+
+	offerUntrack()
+}
+
+// offerUntrack lets the user stop tracking files matched by .gitignore
+func offerUntrack() {
+	files := TrackedIgnored()
+	if len(files) == 0 {
+		return
+	}
+
+	ui.Warn(fmt.Sprintf("%d tracked file(s) match .gitignore:", len(files)))
+	picked := ui.MultiSelect("Untrack (files stay on disk)", files)
+	if len(picked) == 0 {
+		return
+	}
+
+	paths := make([]string, len(picked))
+	for i, n := range picked {
+		paths[i] = files[n]
+	}
+	if err := Untrack(paths); err != nil {
+		ui.Error("Failed to untrack files (see error.log)")
+		return
+	}
+	ui.Success(fmt.Sprintf("Untracked %d file(s) — commit to remove them from the repository", len(paths)))
+}
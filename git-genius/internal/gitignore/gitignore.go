@@ -0,0 +1,231 @@
+package gitignore
+
+import (
+	"embed"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+//go:embed templates/*.gitignore
+var templates embed.FS
+
+// Template is an embedded .gitignore for one project type
+type Template struct {
+	Key  string // file name without extension, e.g. "node"
+	Name string // display name
+}
+
+// Templates lists the embedded templates in menu order
+var Templates = []Template{
+	{"common", "Common (OS, editors, .env)"},
+	{"android", "Android"},
+	{"flutter", "Flutter / Dart"},
+	{"go", "Go"},
+	{"node", "Node.js"},
+	{"python", "Python"},
+	{"java", "Java / Kotlin"},
+	{"rust", "Rust"},
+	{"cpp", "C / C++"},
+	{"ruby", "Ruby"},
+	{"php", "PHP"},
+}
+
+// Lookup finds a template by key (case-insensitive)
+func Lookup(key string) (Template, bool) {
+	for _, t := range Templates {
+		if strings.EqualFold(t.Key, key) {
+			return t, true
+		}
+	}
+	return Template{}, false
+}
+
+// Lines returns the template's content
+func (t Template) Lines() []string {
+	data, err := templates.ReadFile("templates/" + t.Key + ".gitignore")
+	if err != nil {
+		return nil
+	}
+	return strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+}
+
+/* ============================================================
+   Detection
+   ============================================================ */
+
+// markers map files found in a project to template keys
+var markers = []struct {
+	file string
+	key  string
+}{
+	{"pubspec.yaml", "flutter"},
+	{"AndroidManifest.xml", "android"},
+	{"go.mod", "go"},
+	{"package.json", "node"},
+	{"requirements.txt", "python"},
+	{"pyproject.toml", "python"},
+	{"setup.py", "python"},
+	{"Pipfile", "python"},
+	{"pom.xml", "java"},
+	{"build.gradle", "java"},
+	{"build.gradle.kts", "java"},
+	{"Cargo.toml", "rust"},
+	{"CMakeLists.txt", "cpp"},
+	{"Gemfile", "ruby"},
+	{"composer.json", "php"},
+}
+
+// skipDirs are never searched for markers
+var skipDirs = map[string]bool{
+	".git": true, "node_modules": true, "vendor": true, "build": true,
+	"dist": true, "target": true, ".dart_tool": true, ".gradle": true,
+}
+
+// maxDepth limits detection to the top of the project
+const maxDepth = 3
+
+/*
+Detect looks for marker files (go.mod, package.json, pubspec.yaml, ...)
+in the top levels of dir and returns the matching templates, "common"
+first. Java is dropped when the project is Android or Flutter.
+*/
+func Detect(dir string) []Template {
+	found := map[string]bool{"common": true}
+
+	filepath.WalkDir(dir, func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		rel, _ := filepath.Rel(dir, p)
+		depth := strings.Count(rel, string(filepath.Separator))
+
+		if d.IsDir() {
+			if p != dir && (skipDirs[d.Name()] || depth >= maxDepth) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		for _, m := range markers {
+			if d.Name() == m.file {
+				found[m.key] = true
+			}
+		}
+		return nil
+	})
+
+	if found["android"] || found["flutter"] {
+		delete(found, "java")
+	}
+
+	var out []Template
+	for _, t := range Templates {
+		if found[t.Key] {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+/* ============================================================
+   Merging
+   ============================================================ */
+
+/*
+Merge returns the lines of the templates missing from existing, grouped
+under a header comment per template. Patterns already present (anywhere
+in the file or in an earlier template) are skipped; a template with
+nothing new is left out entirely.
+*/
+func Merge(existing string, tpls []Template) string {
+	have := map[string]bool{}
+	for _, l := range strings.Split(existing, "\n") {
+		have[key(l)] = true
+	}
+
+	var b strings.Builder
+	for _, t := range tpls {
+		var block []string
+		for _, l := range t.Lines() {
+			l = strings.TrimSpace(l)
+			switch {
+			case l == "" || strings.HasPrefix(l, "#"):
+				// Keep comments only as separators inside the block
+				if len(block) > 0 && block[len(block)-1] != "" {
+					block = append(block, "")
+				}
+			case !have[key(l)]:
+				have[key(l)] = true
+				block = append(block, l)
+			}
+		}
+		if len(block) > 0 && block[len(block)-1] == "" {
+			block = block[:len(block)-1]
+		}
+		if len(block) == 0 {
+			continue
+		}
+
+		b.WriteString("\n# " + t.Name + "\n")
+		b.WriteString(strings.Join(block, "\n") + "\n")
+	}
+	return b.String()
+}
+
+// Append adds text to the .gitignore in root, starting on a new line
+func Append(root, text string) error {
+	p := filepath.Join(root, ".gitignore")
+	data, err := os.ReadFile(p)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	if len(data) > 0 && !strings.HasSuffix(string(data), "\n") {
+		data = append(data, '\n')
+	}
+	if len(data) == 0 {
+		text = strings.TrimLeft(text, "\n")
+	}
+	return os.WriteFile(p, append(data, text...), 0644)
+}
+
+/*
+AddEntries appends single patterns (e.g. "/big.apk") that are not in the
+.gitignore yet. It returns how many were added.
+*/
+func AddEntries(root string, entries []string) (int, error) {
+	data, err := os.ReadFile(filepath.Join(root, ".gitignore"))
+	if err != nil && !os.IsNotExist(err) {
+		return 0, err
+	}
+
+	have := map[string]bool{}
+	for _, l := range strings.Split(string(data), "\n") {
+		have[key(l)] = true
+	}
+
+	var add []string
+	for _, e := range entries {
+		if !have[key(e)] {
+			have[key(e)] = true
+			add = append(add, e)
+		}
+	}
+	if len(add) == 0 {
+		return 0, nil
+	}
+	return len(add), Append(root, strings.Join(add, "\n")+"\n")
+}
+
+// Read returns the current .gitignore in root ("" when missing)
+func Read(root string) string {
+	data, _ := os.ReadFile(filepath.Join(root, ".gitignore"))
+	return string(data)
+}
+
+// key normalises a pattern for duplicate checks. Only whitespace is
+// trimmed: "build/" matches directories only, so it is not "build"
+func key(pattern string) string {
+	return strings.TrimSpace(pattern)
+}
@@ -0,0 +1,218 @@
+package gitops
+
+import (
+	"fmt"
+	"strings"
+
+	"git-genius/internal/commitmsg"
+	"git-genius/internal/config"
+	"git-genius/internal/system"
+	"git-genius/internal/ui"
+)
+
+/* ============================================================
+   Amend
+   ============================================================ */
+
+// Amend changes the message of the last commit and/or adds staged changes
+func Amend() {
+	if !system.EnsureGitRepo() {
+		return
+	}
+	if Head().Unborn {
+		ui.Error("No commits yet")
+		return
+	}
+
+	_ = system.RunGit("--no-pager", "log", "-1", "--format=%h %s (%an, %ar)")
+	staged, _ := system.GitQuery("diff", "--cached", "--name-only")
+	if staged != "" {
+		ui.Info(fmt.Sprintf("%d staged file(s) can be added", len(strings.Split(staged, "\n"))))
+	}
+
+	fmt.Println("1) Edit message only")
+	fmt.Println("2) Add staged changes (keep message)")
+	fmt.Println("3) Add staged changes and edit message")
+	fmt.Println("0) Cancel")
+
+	choice := ui.Input("Select option")
+	if choice != "1" && choice != "2" && choice != "3" {
+		return
+	}
+
+	if choice != "1" && staged == "" {
+		if !IsDirty() {
+			ui.Warn("No changes to add")
+			return
+		}
+		if !ui.Confirm("Nothing is staged. Stage all changes?") {
+			return
+		}
+		if err := system.RunGit("add", "-A"); err != nil {
+			ui.Error("Failed to stage files")
+			return
+		}
+	}
+
+	// Staged changes become part of the commit: same checks as a commit
+	var env []string
+	if choice != "1" {
+		var ok bool
+		if env, ok = guardSecrets(); !ok {
+			return
+		}
+		if !guardLargeFiles() {
+			return
+		}
+	}
+
+	if !guardRewrite("HEAD") {
+		return
+	}
+
+	args := []string{"commit", "--amend"}
+	if choice == "1" {
+		// Only the message changes, even if something is staged
+		args = append(args, "--only")
+	}
+
+	if choice == "2" {
+		args = append(args, "--no-edit")
+	} else {
+		msg, changed, err := newMessage("HEAD")
+		if err != nil {
+			ui.Error("Invalid commit message: " + err.Error())
+			return
+		}
+		if !changed && choice == "1" {
+			ui.Warn("Message unchanged")
+			return
+		}
+		args = append(args, "-m", msg)
+	}
+
+	recordUndo("amend")
+	if err := system.RunGitEnv(env, args...); err != nil {
+		ui.Error("Amend failed (see error.log)")
+		return
+	}
+	ui.Success("Last commit amended")
+}
+
+/* ============================================================
+   Reword
+   ============================================================ */
+
+// Reword changes the message of one of the recent unpushed commits
+func Reword() {
+	if !system.EnsureGitRepo() {
+		return
+	}
+
+	list := UnpushedCommits()
+	if len(list) == 0 {
+		ui.Info("No unpushed commits to reword")
+		ui.Info("Use amend to change the last commit even if it was pushed")
+		return
+	}
+
+	items := make([]string, len(list))
+	for i, c := range list {
+		items[i] = ui.Yellow + c.Short + ui.Reset + " " + c.Subject
+	}
+
+	i := ui.Select("Reword", items)
+	if i < 0 {
+		return
+	}
+	target := list[i]
+
+	if !guardRewrite(target.Hash) {
+		return
+	}
+
+	msg, changed, err := newMessage(target.Hash)
+	if err != nil {
+		ui.Error("Invalid commit message: " + err.Error())
+		return
+	}
+	if !changed {
+		ui.Warn("Message unchanged")
+		return
+	}
+
+	// Last commit: a plain amend is enough
+	if i == 0 {
+		recordUndo("reword " + target.Short)
+		if err := system.RunGit("commit", "--amend", "--only", "--allow-empty", "-m", msg); err != nil {
+			ui.Error("Reword failed")
+			return
+		}
+		ui.Success("Reworded " + target.Short)
+		return
+	}
+
+	base := rebaseBase(target.Hash)
+	if hasMerges(base) {
+		ui.Error("Merge commits in the range — reword is not supported here")
+		return
+	}
+
+	msgFile, err := messageFile(msg)
+	if err != nil {
+		ui.Error("Failed to prepare reword")
+		return
+	}
+	defer removeWhenDone([]string{msgFile})
+
+	// Oldest first; amend the target's message right after picking it
+	var todo []todoItem
+	for n := i; n >= 0; n-- {
+		c := list[n]
+		todo = append(todo, todoItem{Action: "pick", Hash: c.Hash, Arg: c.Subject})
+		if n == i {
+			todo = append(todo, amendExec(msgFile))
+		}
+	}
+
+	recordUndo("reword " + target.Short)
+	if runRebase(base, todo) {
+		ui.Success("Reworded " + target.Short)
+	}
+}
+
+/* ============================================================
+   Helpers
+   ============================================================ */
+
+// newMessage shows the current message of rev and asks for a new one.
+// The body is kept unless the user replaces it. changed is false when
+// the result equals the current message; err is a failed validation.
+func newMessage(rev string) (msg string, changed bool, err error) {
+	subject, _ := system.GitQuery("log", "-1", "--format=%s", rev)
+	body, _ := system.GitQuery("log", "-1", "--format=%b", rev)
+
+	ui.Info("Current message: " + subject)
+	newSubject := ui.Input("New subject (empty to keep)")
+	if newSubject == "" {
+		newSubject = subject
+	}
+
+	newBody := body
+	if body != "" {
+		fmt.Println(body)
+		if !ui.Confirm("Keep this message body?") {
+			newBody = ui.Input("New body (single line, empty for none)")
+		}
+	}
+
+	msg = newSubject
+	if newBody != "" {
+		msg += "\n\n" + newBody
+	}
+
+	if err := commitmsg.Validate(msg, config.Load()); err != nil {
+		return "", false, err
+	}
+	return msg, newSubject != subject || newBody != body, nil
+}
@@ -0,0 +1,189 @@
+package gitops
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+
+	"git-genius/internal/system"
+	"git-genius/internal/ui"
+)
+
+var (
+	bisectSteps = regexp.MustCompile(`Bisecting: (\d+) revisions? left to test after this \(roughly (\d+) steps?\)`)
+	bisectFound = regexp.MustCompile(`(?m)^([0-9a-f]{64}|[0-9a-f]{40}) is the first bad commit`)
+)
+
+/*
+Bisect walks the user through "git bisect": it starts from a good and a
+bad ref, lets the user mark each candidate (or runs a test command
+automatically), reports the first bad commit and resets afterwards.
+*/
+func Bisect() {
+	if !system.EnsureGitRepo() {
+		return
+	}
+
+	if bisecting() {
+		ui.Warn("A bisect session is already running")
+		if ui.Confirm("Continue it?") {
+			bisectLoop("")
+			return
+		}
+		if ui.Confirm("Reset it?") {
+			bisectReset()
+		}
+		return
+	}
+
+	if IsDirty() {
+		ui.Error("Commit or stash your changes before bisecting")
+		return
+	}
+
+	bad := ui.Input("Bad ref (has the bug) [HEAD]")
+	if bad == "" {
+		bad = "HEAD"
+	}
+	good := ui.Input("Good ref (known to work, e.g. a tag)")
+	if good == "" {
+		ui.Error("A good ref is required")
+		return
+	}
+
+	for _, r := range []string{bad, good} {
+		if _, ok := system.GitQuery("rev-parse", "--verify", "--quiet", r+"^{commit}"); !ok {
+			ui.Error("Unknown ref: " + r)
+			return
+		}
+	}
+	if !IsMerged(good, bad) {
+		ui.Error(good + " is not an ancestor of " + bad)
+		return
+	}
+
+	out, err := system.GitOutput("bisect", "start", bad, good)
+	if err != nil {
+		ui.Error("Failed to start bisect (see error.log)")
+		return
+	}
+
+	ui.Success("Bisect started")
+	fmt.Println(out)
+
+	cmd := ""
+	if ui.Confirm("Run a test command automatically for each step?") {
+		cmd = ui.Input("Command (exit 0 = good, 125 = skip, other = bad)")
+	}
+
+	if cmd != "" {
+		bisectRun(cmd)
+		return
+	}
+	if !reportFirstBad(out) {
+		bisectLoop(out)
+	}
+}
+
+// bisectLoop asks the user to judge each candidate until git finds the culprit
+func bisectLoop(last string) {
+	for {
+		fmt.Println()
+		ui.Header("Bisect")
+		if m := bisectSteps.FindStringSubmatch(last); m != nil {
+			ui.Info(fmt.Sprintf("%s revision(s) left after this — roughly %s step(s)", m[1], m[2]))
+		}
+		_ = system.RunGit("--no-pager", "log", "-1", "--format=Testing %C(yellow)%h%Creset %s (%an, %ar)")
+
+		fmt.Println()
+		fmt.Println("g) Good   b) Bad   s) Skip (cannot test)")
+		fmt.Println("v) View commit   r) Run a command for the rest   q) Stop and reset")
+
+		var verdict string
+		switch ui.Input("Select option") {
+		case "g":
+			verdict = "good"
+		case "b":
+			verdict = "bad"
+		case "s":
+			verdict = "skip"
+		case "v":
+			CommitDetail("HEAD")
+			continue
+		case "r":
+			if cmd := ui.Input("Command (exit 0 = good, 125 = skip, other = bad)"); cmd != "" {
+				bisectRun(cmd)
+				return
+			}
+			continue
+		case "q":
+			bisectReset()
+			return
+		default:
+			ui.Error("Invalid option, please try again")
+			continue
+		}
+
+		out, err := system.GitOutput("bisect", verdict)
+		if err != nil {
+			// git exits non-zero when only skipped commits remain
+			out, _ = system.GitQuery("bisect", "log")
+			ui.Warn("Bisect cannot narrow further (too many skipped commits)")
+			fmt.Println(out)
+			bisectReset()
+			return
+		}
+		if reportFirstBad(out) {
+			return
+		}
+		last = out
+	}
+}
+
+// bisectRun lets git test every remaining step with cmd
+func bisectRun(cmd string) {
+	ui.Info("Running: " + cmd)
+	out, err := system.GitOutput("bisect", "run", "sh", "-c", cmd)
+	fmt.Println(out)
+
+	if err != nil || !reportFirstBad(out) {
+		ui.Error("Automatic bisect did not find the first bad commit (see error.log)")
+		bisectReset()
+	}
+}
+
+// reportFirstBad shows the culprit and resets when git has found it
+func reportFirstBad(out string) bool {
+	m := bisectFound.FindStringSubmatch(out)
+	if m == nil {
+		return false
+	}
+
+	fmt.Println()
+	ui.Success("First bad commit found: " + short(m[1]))
+	_ = system.RunGit("--no-pager", "show", "--stat", "--format=fuller", m[1])
+
+	bisectReset()
+
+	if ui.Confirm("Open the commit details?") {
+		CommitDetail(m[1])
+	}
+	return true
+}
+
+func bisectReset() {
+	if err := system.RunGit("bisect", "reset"); err != nil {
+		ui.Error("bisect reset failed — run it manually")
+		return
+	}
+	ui.Info("Bisect reset — back on " + Head().String())
+}
+
+func bisecting() bool {
+	p := system.GitPath("BISECT_LOG")
+	if p == "" {
+		return false
+	}
+	_, err := os.Stat(p)
+	return err == nil
+}
@@ -0,0 +1,225 @@
+package gitops
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"git-genius/internal/system"
+	"git-genius/internal/ui"
+)
+
+// BlameLine is one line of a file annotated with its last change
+type BlameLine struct {
+	Hash   string
+	Author string
+	Time   time.Time
+	Line   int
+	Text   string
+}
+
+/* ============================================================
+   File History
+   ============================================================ */
+
+// FileHistory lists commits touching a path (following renames)
+func FileHistory() {
+	if !system.EnsureGitRepo() {
+		return
+	}
+
+	path := ui.Input("File path")
+	if path == "" {
+		ui.Error("File path cannot be empty")
+		return
+	}
+
+	out, err := system.GitOutput("log", "--follow", "--name-status",
+		"--format=\x1e%H\x1f%h\x1f%an\x1f%ar\x1f%s", "--", path)
+	if err != nil || out == "" {
+		ui.Error("No history found for " + path)
+		return
+	}
+
+	type entry struct {
+		hash, line string
+	}
+
+	var entries []entry
+	for _, block := range strings.Split(out, "\x1e")[1:] {
+		head, files, _ := strings.Cut(block, "\n")
+		f := strings.Split(head, "\x1f")
+		if len(f) < 5 {
+			continue
+		}
+
+		line := ui.Yellow + f[1] + ui.Reset + " " + f[4] + " " + ui.Cyan + "(" + f[2] + ", " + f[3] + ")" + ui.Reset
+		// Show the name the file had in that commit when it was renamed
+		for _, st := range strings.Split(strings.TrimSpace(files), "\n") {
+			parts := strings.Split(st, "\t")
+			if len(parts) == 3 && strings.HasPrefix(parts[0], "R") {
+				line += ui.Magenta + "  renamed " + parts[1] + " → " + parts[2] + ui.Reset
+			}
+		}
+		entries = append(entries, entry{f[0], line})
+	}
+
+	items := make([]string, len(entries))
+	for i, e := range entries {
+		items[i] = e.line
+	}
+
+	for {
+		fmt.Println()
+		ui.Header(fmt.Sprintf("History of %s — %d commit(s)", path, len(entries)))
+
+		i := ui.Select("Commit", items)
+		if i < 0 {
+			return
+		}
+		CommitDetail(entries[i].hash)
+	}
+}
+
+/* ============================================================
+   Blame
+   ============================================================ */
+
+// BlameView annotates each line of a file and jumps to commit details
+func BlameView() {
+	if !system.EnsureGitRepo() {
+		return
+	}
+
+	path := ui.Input("File path")
+	if path == "" {
+		ui.Error("File path cannot be empty")
+		return
+	}
+
+	lines, err := Blame(path)
+	if err != nil || len(lines) == 0 {
+		ui.Error("Cannot blame " + path + " (is it tracked?)")
+		return
+	}
+
+	rendered := make([]string, len(lines))
+	for i, l := range lines {
+		author := l.Author
+		if r := []rune(author); len(r) > 12 {
+			author = string(r[:12])
+		}
+		rendered[i] = fmt.Sprintf("%s%s%s %-12s %s%6s%s %4d│ %s",
+			ui.Yellow, short(l.Hash), ui.Reset, author,
+			ui.Cyan, age(l.Time), ui.Reset, l.Line, l.Text)
+	}
+
+	for {
+		fmt.Println()
+		ui.Header("Blame: " + path)
+		ui.Page(rendered)
+
+		in := ui.Input("Line number to open its commit (empty to go back)")
+		if in == "" {
+			return
+		}
+
+		n, err := strconv.Atoi(in)
+		if err != nil || n < 1 || n > len(lines) {
+			ui.Error("Invalid line number")
+			continue
+		}
+
+		if strings.Trim(lines[n-1].Hash, "0") == "" {
+			ui.Info("Line " + in + " is not committed yet")
+			continue
+		}
+		CommitDetail(lines[n-1].Hash)
+	}
+}
+
+// Blame parses "git blame --porcelain" for path
+func Blame(path string) ([]BlameLine, error) {
+	out, err := system.GitOutput("blame", "--porcelain", "--", path)
+	if err != nil {
+		return nil, err
+	}
+
+	type meta struct {
+		author string
+		time   time.Time
+	}
+	commits := map[string]*meta{}
+
+	var lines []BlameLine
+	var cur BlameLine
+
+	for _, l := range strings.Split(out, "\n") {
+		if strings.HasPrefix(l, "\t") {
+			m := commits[cur.Hash]
+			cur.Text = l[1:]
+			cur.Author, cur.Time = m.author, m.time
+			lines = append(lines, cur)
+			continue
+		}
+
+		f := strings.Fields(l)
+		if blameHeader(f) {
+			cur = BlameLine{Hash: f[0]}
+			cur.Line, _ = strconv.Atoi(f[2])
+			if commits[cur.Hash] == nil {
+				commits[cur.Hash] = &meta{}
+			}
+			continue
+		}
+
+		key, val, _ := strings.Cut(l, " ")
+		m := commits[cur.Hash]
+		if m == nil {
+			continue
+		}
+		switch key {
+		case "author":
+			m.author = val
+		case "author-time":
+			sec, _ := strconv.ParseInt(val, 10, 64)
+			m.time = time.Unix(sec, 0)
+		}
+	}
+
+	return lines, nil
+}
+
+// blameHeader matches "<hash> <orig line> <final line> [<count>]";
+// the hash is 40 (SHA-1) or 64 (SHA-256) hex digits
+func blameHeader(f []string) bool {
+	if len(f) < 3 || len(f) > 4 || len(f[0]) < 40 {
+		return false
+	}
+	if strings.Trim(f[0], "0123456789abcdef") != "" {
+		return false
+	}
+	for _, n := range f[1:] {
+		if _, err := strconv.Atoi(n); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// age renders a compact relative age like "3d", "5mo" or "2y"
+func age(t time.Time) string {
+	d := time.Since(t)
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	case d < 30*24*time.Hour:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	case d < 365*24*time.Hour:
+		return fmt.Sprintf("%dmo", int(d.Hours()/24/30))
+	}
+	return fmt.Sprintf("%dy", int(d.Hours()/24/365))
+}
@@ -0,0 +1,394 @@
+package gitops
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"git-genius/internal/config"
+	"git-genius/internal/system"
+	"git-genius/internal/ui"
+)
+
+// Branch is a local or remote-tracking branch with its last commit
+type Branch struct {
+	Name     string // short name, e.g. "main" or "origin/main"
+	Remote   bool
+	Current  bool
+	Upstream string // tracking branch of a local branch
+	Track    string // e.g. "[ahead 1, behind 2]" or "[gone]"
+	Commit   string
+	Date     string // relative committer date
+	Time     time.Time
+	Subject  string
+}
+
+// String renders a branch for lists and pickers
+func (b Branch) String() string {
+	mark := "  "
+	if b.Current {
+		mark = ui.Green + "* " + ui.Reset
+	}
+
+	s := mark + b.Name + "  " + ui.Yellow + b.Commit + ui.Reset + " " + b.Subject + " (" + b.Date + ")"
+	if b.Upstream != "" {
+		s += "  " + ui.Cyan + "→ " + b.Upstream
+		if b.Track != "" {
+			s += " " + b.Track
+		}
+		s += ui.Reset
+	}
+	return s
+}
+
+/* ============================================================
+   Queries
+   ============================================================ */
+
+// Branches lists local branches followed by remote-tracking branches
+func Branches() []Branch {
+	const sep = "\x1f"
+	format := strings.Join([]string{
+		"%(HEAD)", "%(refname)", "%(refname:short)", "%(upstream:short)",
+		"%(upstream:track)", "%(objectname:short)", "%(committerdate:relative)",
+		"%(committerdate:unix)", "%(subject)",
+	}, sep)
+
+	out, err := system.GitOutput("for-each-ref", "--format="+format, "refs/heads", "refs/remotes")
+	if err != nil || out == "" {
+		return nil
+	}
+
+	var list []Branch
+	for _, l := range strings.Split(out, "\n") {
+		f := strings.Split(l, sep)
+		if len(f) < 9 || strings.HasSuffix(f[1], "/HEAD") {
+			continue
+		}
+		unix, _ := strconv.ParseInt(f[7], 10, 64)
+		list = append(list, Branch{
+			Name:     f[2],
+			Remote:   strings.HasPrefix(f[1], "refs/remotes/"),
+			Current:  f[0] == "*",
+			Upstream: f[3],
+			Track:    f[4],
+			Commit:   f[5],
+			Date:     f[6],
+			Time:     time.Unix(unix, 0),
+			Subject:  f[8],
+		})
+	}
+	return list
+}
+
+// LocalBranches returns only local branches
+func LocalBranches() []Branch {
+	return filterBranches(func(b Branch) bool { return !b.Remote })
+}
+
+// RemoteBranches returns only remote-tracking branches
+func RemoteBranches() []Branch {
+	return filterBranches(func(b Branch) bool { return b.Remote })
+}
+
+// BranchExists reports whether a local branch with this name exists
+func BranchExists(name string) bool {
+	_, ok := system.GitQuery("show-ref", "--verify", "--quiet", "refs/heads/"+name)
+	return ok
+}
+
+// IsMerged reports whether branch is fully contained in target
+func IsMerged(branch, target string) bool {
+	_, ok := system.GitQuery("merge-base", "--is-ancestor", branch, target)
+	return ok
+}
+
+func filterBranches(keep func(Branch) bool) []Branch {
+	var out []Branch
+	for _, b := range Branches() {
+		if keep(b) {
+			out = append(out, b)
+		}
+	}
+	return out
+}
+
+/* ============================================================
+   Branch Manager
+   ============================================================ */
+
+// BranchManager is the interactive menu for everything branch related
+func BranchManager() {
+	if !system.EnsureGitRepo() {
+		return
+	}
+
+	for {
+		fmt.Println()
+		ui.Header("Branch Manager")
+		fmt.Println("Current : " + Head().String())
+		fmt.Println()
+		fmt.Println("1) List branches")
+		fmt.Println("2) Switch branch")
+		fmt.Println("3) Create branch")
+		fmt.Println("4) Rename branch")
+		fmt.Println("5) Delete branch")
+		fmt.Println("6) Set upstream")
+		fmt.Println("7) Checkout remote branch")
+		fmt.Println("8) Clean up stale branches")
+		fmt.Println("0) Back")
+
+		switch ui.Input("Select option") {
+		case "1":
+			listBranches()
+		case "2":
+			switchBranchInteractive()
+		case "3":
+			createBranch()
+		case "4":
+			renameBranch()
+		case "5":
+			deleteBranch()
+		case "6":
+			setUpstream()
+		case "7":
+			checkoutRemoteBranch()
+		case "8":
+			CleanupBranches()
+		case "0":
+			return
+		default:
+			ui.Error("Invalid option, please try again")
+		}
+	}
+}
+
+/*
+SwitchBranch checks out an existing local branch.
+Unlike "checkout -B" it never resets the branch. Local changes can be
+carried over or stashed first.
+*/
+func SwitchBranch(name string) bool {
+	if IsDirty() {
+		ui.Warn("You have uncommitted changes")
+		fmt.Println("1) Take them along to " + name)
+		fmt.Println("2) Stash them first")
+		fmt.Println("3) Cancel")
+
+		switch ui.Input("Select option") {
+		case "1":
+		case "2":
+			if err := StashPush("genius: switching to "+name, true); err != nil {
+				ui.Error("Failed to stash changes")
+				return false
+			}
+			ui.Info("Changes stashed — restore them from the Stash manager")
+		default:
+			return false
+		}
+	}
+
+	recordUndo("switch to " + name)
+	if err := system.RunGit("checkout", name); err != nil {
+		ui.Error("Failed to switch branch (uncommitted changes may conflict)")
+		return false
+	}
+	ui.Success("Switched to branch: " + name)
+	return true
+}
+
+func listBranches() {
+	ui.Info("Local branches")
+	for _, b := range LocalBranches() {
+		fmt.Println(b)
+	}
+
+	fmt.Println()
+	ui.Info("Remote branches")
+	for _, b := range RemoteBranches() {
+		fmt.Println(b)
+	}
+}
+
+func switchBranchInteractive() {
+	b, ok := pickBranch("Switch to", LocalBranches())
+	if !ok {
+		return
+	}
+	if b.Current {
+		ui.Info("Already on " + b.Name)
+		return
+	}
+	SwitchBranch(b.Name)
+}
+
+func createBranch() {
+	name := ui.Input("New branch name")
+	if name == "" {
+		ui.Error("Branch name cannot be empty")
+		return
+	}
+	if _, ok := system.GitQuery("check-ref-format", "--branch", name); !ok {
+		ui.Error("Invalid branch name: " + name)
+		return
+	}
+	if BranchExists(name) {
+		ui.Error("Branch already exists: " + name)
+		return
+	}
+
+	base := "HEAD"
+	ui.Info("Choose the base (empty = current HEAD)")
+	if b, ok := pickBranch("Base branch", Branches()); ok {
+		base = b.Name
+	}
+
+	recordUndo("create branch " + name)
+	if ui.Confirm("Switch to " + name + " now?") {
+		if err := system.RunGit("checkout", "-b", name, base); err != nil {
+			ui.Error("Failed to create branch")
+			return
+		}
+		ui.Success("Created and switched to: " + name + " (from " + base + ")")
+		return
+	}
+
+	if err := system.RunGit("branch", name, base); err != nil {
+		ui.Error("Failed to create branch")
+		return
+	}
+	ui.Success("Created branch: " + name + " (from " + base + ")")
+}
+
+func renameBranch() {
+	b, ok := pickBranch("Rename", LocalBranches())
+	if !ok {
+		return
+	}
+
+	name := ui.Input("New name for " + b.Name)
+	if name == "" {
+		ui.Error("Branch name cannot be empty")
+		return
+	}
+	if _, ok := system.GitQuery("check-ref-format", "--branch", name); !ok {
+		ui.Error("Invalid branch name: " + name)
+		return
+	}
+	if BranchExists(name) {
+		ui.Error("Branch already exists: " + name)
+		return
+	}
+
+	recordUndo("rename " + b.Name)
+	if err := system.RunGit("branch", "-m", b.Name, name); err != nil {
+		ui.Error("Failed to rename branch")
+		return
+	}
+
+	cfg := config.Load()
+	if cfg.Branch == b.Name {
+		cfg.Branch = name
+		config.Save(cfg)
+		ui.Info("Default branch updated to: " + name)
+	}
+
+	ui.Success("Renamed " + b.Name + " → " + name)
+}
+
+func deleteBranch() {
+	b, ok := pickBranch("Delete", LocalBranches())
+	if !ok {
+		return
+	}
+	if b.Current {
+		ui.Error("Cannot delete the checked out branch — switch first")
+		return
+	}
+
+	def := DefaultBranch()
+	if b.Name == def {
+		ui.Warn(b.Name + " is the configured default branch")
+	}
+
+	if IsMerged(b.Name, def) {
+		ui.Info(b.Name + " is fully merged into " + def)
+		if !ui.Confirm("Delete " + b.Name + "?") {
+			return
+		}
+	} else {
+		n, _ := system.GitQuery("rev-list", "--count", def+".."+b.Name)
+		ui.Warn(fmt.Sprintf("%s is NOT merged into %s — %s commit(s) would be lost", b.Name, def, n))
+		if !ui.Confirm("Force delete " + b.Name + "?") {
+			return
+		}
+	}
+
+	recordUndo("delete " + b.Name)
+	if err := system.RunGit("branch", "-D", b.Name); err != nil {
+		ui.Error("Failed to delete branch")
+		return
+	}
+	ui.Success("Deleted branch: " + b.Name + " (was " + b.Commit + ")")
+
+	if b.Upstream != "" && b.Track != "[gone]" && ui.Confirm("Also delete "+b.Upstream+" on the remote?") {
+		deleteRemoteBranch(b.Upstream)
+	}
+}
+
+func setUpstream() {
+	b, ok := pickBranch("Branch", LocalBranches())
+	if !ok {
+		return
+	}
+
+	up, ok := pickBranch("Upstream for "+b.Name, RemoteBranches())
+	if !ok {
+		return
+	}
+
+	recordConfigUndo("set upstream of "+b.Name, "branch."+b.Name+".remote", "branch."+b.Name+".merge")
+	if err := system.RunGit("branch", "--set-upstream-to="+up.Name, b.Name); err != nil {
+		ui.Error("Failed to set upstream")
+		return
+	}
+	ui.Success(b.Name + " now tracks " + up.Name)
+}
+
+func checkoutRemoteBranch() {
+	r, ok := pickBranch("Checkout", RemoteBranches())
+	if !ok {
+		return
+	}
+
+	_, local, _ := strings.Cut(r.Name, "/")
+	if BranchExists(local) {
+		ui.Error("Local branch " + local + " already exists — switch to it instead")
+		return
+	}
+
+	recordUndo("checkout " + r.Name)
+	if err := system.RunGit("checkout", "--track", r.Name); err != nil {
+		ui.Error("Failed to checkout " + r.Name)
+		return
+	}
+	ui.Success("Created " + local + " tracking " + r.Name)
+}
+
+/* ============================================================
+   Helpers
+   ============================================================ */
+
+func pickBranch(label string, list []Branch) (Branch, bool) {
+	items := make([]string, len(list))
+	for i, b := range list {
+		items[i] = b.String()
+	}
+
+	i := ui.Select(label, items)
+	if i < 0 {
+		return Branch{}, false
+	}
+	return list[i], true
+}
@@ -0,0 +1,157 @@
+package gitops
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"git-genius/internal/config"
+	"git-genius/internal/system"
+	"git-genius/internal/ui"
+)
+
+// staleBranch is a cleanup candidate and why it was picked
+type staleBranch struct {
+	Branch
+	Reason string
+}
+
+/*
+CleanupBranches finds merged local branches, local branches whose
+upstream is gone and remote branches untouched for N days, then deletes
+the ones the user selects.
+*/
+func CleanupBranches() {
+	if !system.EnsureGitRepo() {
+		return
+	}
+
+	days := 90
+	if d := ui.Input("Remote branches are stale after how many days? [90]"); d != "" {
+		n, err := strconv.Atoi(d)
+		if err != nil || n < 1 {
+			ui.Error("Please enter a positive number of days")
+			return
+		}
+		days = n
+	}
+
+	ui.Info("Pruning deleted remote branches...")
+	if err := system.RunGit("fetch", "--all", "--prune"); err != nil {
+		ui.Warn("Fetch failed — results may be out of date")
+	}
+
+	candidates := staleBranches(time.Duration(days) * 24 * time.Hour)
+	if len(candidates) == 0 {
+		ui.Success("No stale branches found")
+		return
+	}
+
+	items := make([]string, len(candidates))
+	for i, c := range candidates {
+		kind := "local "
+		if c.Remote {
+			kind = "remote"
+		}
+		items[i] = fmt.Sprintf("%s %s  %s(%s, %s)%s", kind, c.Name, ui.Yellow, c.Reason, c.Date, ui.Reset)
+	}
+
+	ui.Header("Stale Branches")
+	picked := ui.MultiSelect("Select branches to delete", items)
+	if len(picked) == 0 {
+		ui.Info("Nothing deleted")
+		return
+	}
+
+	if !ui.Confirm(fmt.Sprintf("Delete %d branch(es)?", len(picked))) {
+		return
+	}
+
+	alsoRemote := false
+	for _, i := range picked {
+		c := candidates[i]
+		if !c.Remote && c.Upstream != "" && c.Track != "[gone]" {
+			alsoRemote = ui.Confirm("Also delete the remote branches of selected local branches?")
+			break
+		}
+	}
+
+	recordUndo("branch cleanup")
+	deleted := 0
+	for _, i := range picked {
+		c := candidates[i]
+
+		if c.Remote {
+			if deleteRemoteBranch(c.Name) {
+				deleted++
+			}
+			continue
+		}
+
+		if err := system.RunGit("branch", "-D", c.Name); err != nil {
+			ui.Error("Failed to delete " + c.Name)
+			continue
+		}
+		ui.Success("Deleted " + c.Name + " (was " + c.Commit + ")")
+		deleted++
+
+		if alsoRemote && c.Upstream != "" && c.Track != "[gone]" {
+			deleteRemoteBranch(c.Upstream)
+		}
+	}
+
+	ui.Success(fmt.Sprintf("Cleanup finished: %d of %d branch(es) deleted", deleted, len(picked)))
+}
+
+// staleBranches collects cleanup candidates in display order
+func staleBranches(maxAge time.Duration) []staleBranch {
+	cfg := config.Load()
+	def := cfg.Branch
+	protected := map[string]bool{def: true, "main": true, "master": true}
+
+	var out []staleBranch
+
+	for _, b := range LocalBranches() {
+		if b.Current || protected[b.Name] {
+			continue
+		}
+		switch {
+		case b.Track == "[gone]":
+			reason := "upstream gone"
+			if !IsMerged(b.Name, def) {
+				reason += ", has unmerged commits"
+			}
+			out = append(out, staleBranch{b, reason})
+		case IsMerged(b.Name, def):
+			out = append(out, staleBranch{b, "merged into " + def})
+		}
+	}
+
+	cutoff := time.Now().Add(-maxAge)
+	for _, b := range RemoteBranches() {
+		_, name, _ := strings.Cut(b.Name, "/")
+		if protected[name] || b.Time.After(cutoff) {
+			continue
+		}
+		days := int(time.Since(b.Time).Hours() / 24)
+		out = append(out, staleBranch{b, fmt.Sprintf("untouched for %d days", days)})
+	}
+
+	return out
+}
+
+// deleteRemoteBranch deletes "remote/name" on the remote itself
+func deleteRemoteBranch(full string) bool {
+	remote, name, ok := strings.Cut(full, "/")
+	if !ok {
+		return false
+	}
+
+	if err := system.RunGit("push", remote, "--delete", name); err != nil {
+		ui.Error("Failed to delete remote branch " + full)
+		return false
+	}
+	ui.Success("Deleted remote branch " + full)
+	return true
+}
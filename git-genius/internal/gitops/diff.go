@@ -0,0 +1,84 @@
+package gitops
+
+import (
+	"fmt"
+
+	"git-genius/internal/diff"
+	"git-genius/internal/system"
+	"git-genius/internal/ui"
+)
+
+/*
+ViewPatch runs a git command that prints a patch (diff, show, stash show)
+and pages it with colored, word-level highlighting. It returns false when
+git failed; an empty patch only prints a notice.
+*/
+func ViewPatch(args ...string) bool {
+	// Diff options go after the subcommand ("stash show", not "stash")
+	n := 1
+	if args[0] == "stash" {
+		n = 2
+	}
+	args = append(append(append([]string{}, args[:n]...), "--no-color", "--no-ext-diff"), args[n:]...)
+
+	out, err := system.GitOutput(args...)
+	if err != nil {
+		return false
+	}
+
+	files := diff.Parse(out)
+	if len(files) == 0 {
+		ui.Info("No changes")
+		return true
+	}
+
+	ui.Page(diff.Render(files))
+	return true
+}
+
+// DiffViewer lets the user pick what to compare and shows the diff
+func DiffViewer() {
+	if !system.EnsureGitRepo() {
+		return
+	}
+
+	for {
+		fmt.Println()
+		ui.Header("Diff Viewer")
+		fmt.Println("1) Unstaged changes (working tree vs index)")
+		fmt.Println("2) Staged changes (index vs HEAD)")
+		fmt.Println("3) All local changes (working tree vs HEAD)")
+		fmt.Println("4) Between two refs")
+		fmt.Println("0) Back")
+
+		ok := true
+		switch ui.Input("Select option") {
+		case "1":
+			ok = ViewPatch("diff")
+		case "2":
+			ok = ViewPatch("diff", "--cached")
+		case "3":
+			ok = ViewPatch("diff", "HEAD")
+		case "4":
+			from := ui.Input("From ref (branch, tag or commit)")
+			to := ui.Input("To ref [HEAD]")
+			if to == "" {
+				to = "HEAD"
+			}
+			if from == "" {
+				ui.Error("A starting ref is required")
+				continue
+			}
+			ok = ViewPatch("diff", "-M", from, to)
+		case "0":
+			return
+		default:
+			ui.Error("Invalid option, please try again")
+			continue
+		}
+
+		if !ok {
+			ui.Error("Failed to compute diff (see error.log)")
+		}
+	}
+}
@@ -1,7 +1,12 @@
 package gitops
 
 import (
+	"os"
+	"strings"
+
+	"git-genius/internal/commitmsg"
 	"git-genius/internal/config"
+	"git-genius/internal/diff"
 	"git-genius/internal/system"
 	"git-genius/internal/ui"
 )
@@ -10,7 +15,13 @@ import (
    Helpers
    ============================================================ */
 
+// CurrentBranch returns the branch actually checked out (empty when detached)
 func CurrentBranch() string {
+	return Head().Branch
+}
+
+// DefaultBranch returns the branch configured during setup
+func DefaultBranch() string {
 	return config.Load().Branch
 }
 
@@ -18,6 +29,18 @@ func CurrentRemote() string {
 	return config.Load().Remote
 }
 
+// GitConfig reads a git config value (empty when unset)
+func GitConfig(key string) string {
+	v, _ := system.GitQuery("config", "--get", key)
+	return v
+}
+
+// IsDirty reports whether the work tree has staged, unstaged or untracked changes
+func IsDirty() bool {
+	out, ok := system.GitQuery("status", "--porcelain")
+	return ok && out != ""
+}
+
 /* ============================================================
    Core Git Operations
    ============================================================ */
@@ -32,119 +55,221 @@ func Status() {
 	}
 }
 
-func Push(msg string) {
-	if msg == "" {
-		ui.Error("Commit message cannot be empty")
-		return
+/*
+Push stages everything, commits and pushes the current branch.
+An empty msg asks for the message interactively after staging.
+It returns true when the commit was pushed.
+*/
+func Push(msg string) bool {
+	cfg := config.Load()
+
+	// Reject a bad message before touching the index
+	if msg != "" {
+		if err := commitmsg.Validate(msg, cfg); err != nil {
+			ui.Error("Invalid commit message: " + err.Error())
+			return false
+		}
 	}
 
 	if !system.EnsureGitRepo() {
-		return
+		return false
+	}
+
+	branch, ok := EnsureBranch("push")
+	if !ok {
+		return false
 	}
 
 	if err := system.RunGit("add", "."); err != nil {
 		ui.Error("Failed to stage files")
-		return
+		return false
 	}
 
-	if err := system.RunGit("commit", "-m", msg); err != nil {
+	if _, clean := system.GitQuery("diff", "--cached", "--quiet"); clean {
 		ui.Warn("Nothing to commit")
-		return
+		return false
 	}
 
-	cfg := config.Load()
-	if err := system.RunGit("push", cfg.Remote, cfg.Branch); err != nil {
-		ui.Error("Push failed (see error.log)")
-		return
+	env, ok := guardSecrets()
+	if !ok {
+		return false
 	}
 
-	ui.Success("Changes pushed successfully")
-}
+	if !guardLargeFiles() {
+		return false
+	}
 
-func Pull() {
-	if !system.EnsureGitRepo() {
-		return
+	if cfg.ReviewDiff {
+		ViewPatch("diff", "--cached", "-M")
+		if !ui.Confirm("Commit these changes?") {
+			ui.Warn("Commit cancelled — changes are left staged")
+			return false
+		}
 	}
 
-	cfg := config.Load()
+	if msg == "" {
+		if msg = AskCommitMessage(); msg == "" {
+			ui.Warn("Commit cancelled — changes are left staged")
+			return false
+		}
+	}
 
-	ui.Info("Fetching latest changes...")
-	if err := system.RunGit("fetch", cfg.Remote, cfg.Branch); err != nil {
-		ui.Error("Fetch failed")
-		return
+	recordUndo("push")
+	if err := system.RunGitEnv(env, "commit", "-m", msg); err != nil {
+		ui.Error("Commit failed (see error.log)")
+		return false
 	}
 
-	ui.Info("Merging changes...")
-	if err := system.RunGit("merge", cfg.Remote+"/"+cfg.Branch); err != nil {
-		ui.Error("Merge conflict detected — resolve manually")
-		return
+	if err := system.RunGit("push", cfg.Remote, branch); err != nil {
+		ui.Error("Push failed (see error.log)")
+		return false
 	}
 
-	ui.Success("Pulled latest changes")
+	ui.Success("Changes pushed successfully")
+
+	if len(cfg.PushRemotes) > 0 {
+		PushToRemotes(branch, cfg.PushRemotes)
+	}
+	return true
 }
 
-func Fetch() {
-	if !system.EnsureGitRepo() {
-		return
+/*
+CommitAll stages all changes and commits them locally without pushing.
+It returns false when there is nothing to commit or the commit failed.
+*/
+func CommitAll(msg string) bool {
+	if err := commitmsg.Validate(msg, config.Load()); err != nil {
+		ui.Error("Invalid commit message: " + err.Error())
+		return false
 	}
 
-	if err := system.RunGit("fetch", "--all"); err != nil {
-		ui.Error("Fetch failed")
-		return
+	if _, ok := EnsureBranch("commit"); !ok {
+		return false
 	}
-	ui.Success("Fetched all remotes")
-}
 
-/* ============================================================
-   Branch & Remote
-   ============================================================ */
+	if err := system.RunGit("add", "."); err != nil {
+		ui.Error("Failed to stage files")
+		return false
+	}
 
-func SwitchBranch() {
-	if !system.EnsureGitRepo() {
-		return
+	if _, clean := system.GitQuery("diff", "--cached", "--quiet"); clean {
+		ui.Warn("Nothing to commit")
+		return false
 	}
 
-	name := ui.Input("New branch name")
-	if name == "" {
-		ui.Error("Branch name cannot be empty")
-		return
+	env, ok := guardSecrets()
+	if !ok {
+		return false
 	}
 
-	if err := system.RunGit("checkout", "-B", name); err != nil {
-		ui.Error("Failed to switch branch")
-		return
+	if !guardLargeFiles() {
+		return false
 	}
 
+	recordUndo("commit")
+	if err := system.RunGitEnv(env, "commit", "-m", msg); err != nil {
+		ui.Error("Commit failed (see error.log)")
+		return false
+	}
+	return true
+}
+
+/*
+AskCommitMessage prompts for a commit message until it passes validation.
+A suggestion derived from the staged diff can be accepted or edited, and
+the composer is offered when Conventional Commits are enforced.
+It returns an empty string when the user gives up.
+*/
+func AskCommitMessage() string {
 	cfg := config.Load()
-	cfg.Branch = name
-	config.Save(cfg)
+	suggestion := suggestMessage(cfg)
+
+	for {
+		var msg string
+		switch {
+		case cfg.CommitStyle == commitmsg.StyleConventional && ui.Confirm("Use the commit composer?"):
+			msg, _ = commitmsg.Compose(cfg, suggestion)
+
+		case suggestion != "":
+			ui.Info("Suggested: " + suggestion)
+			switch in := ui.Input("Commit message (Enter = accept, e = edit suggestion, q = cancel)"); in {
+			case "":
+				msg = suggestion
+			case "e":
+				msg = editMessage(suggestion)
+			case "q":
+				return ""
+			default:
+				msg = in
+			}
+
+		default:
+			msg = ui.Input("Commit message")
+		}
+
+		if msg == "" {
+			return ""
+		}
+
+		err := commitmsg.Validate(msg, cfg)
+		if err == nil {
+			return msg
+		}
+		ui.Error("Invalid commit message: " + err.Error())
+		if !ui.Confirm("Try again?") {
+			return ""
+		}
+	}
+}
 
-	ui.Success("Switched to branch: " + name)
+// suggestMessage proposes a message for the staged changes (offline)
+func suggestMessage(cfg config.Config) string {
+	out, err := system.GitOutput("diff", "--cached", "-M", "--no-color", "--no-ext-diff")
+	if err != nil {
+		return ""
+	}
+	return commitmsg.Suggest(diff.Parse(out), cfg)
 }
 
-func SwitchRemote() {
-	if !system.EnsureGitRepo() {
-		return
+// editMessage opens msg in the user's editor and returns the result
+// without comment lines
+func editMessage(msg string) string {
+	f, err := os.CreateTemp("", "genius-msg-*")
+	if err != nil {
+		return msg
 	}
+	defer os.Remove(f.Name())
 
-	name := ui.Input("Remote name")
-	url := ui.Input("Remote URL")
+	f.WriteString(msg + "\n\n# Lines starting with # are ignored\n")
+	f.Close()
 
-	if name == "" || url == "" {
-		ui.Error("Remote name and URL are required")
-		return
+	if err := system.OpenEditor(f.Name()); err != nil {
+		ui.Error("Could not open editor: " + err.Error())
+		return msg
 	}
 
-	_ = system.RunGit("remote", "remove", name)
+	data, err := os.ReadFile(f.Name())
+	if err != nil {
+		return msg
+	}
 
-	if err := system.RunGit("remote", "add", name, url); err != nil {
-		ui.Error("Failed to add remote")
-		return
+	var lines []string
+	for _, l := range strings.Split(string(data), "\n") {
+		if !strings.HasPrefix(l, "#") {
+			lines = append(lines, l)
+		}
 	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
 
-	cfg := config.Load()
-	cfg.Remote = name
-	config.Save(cfg)
+func Fetch() {
+	if !system.EnsureGitRepo() {
+		return
+	}
 
-	ui.Success("Remote set to: " + name)
+	if err := system.RunGit("fetch", "--all"); err != nil {
+		ui.Error("Fetch failed")
+		return
+	}
+	ui.Success("Fetched all remotes")
 }
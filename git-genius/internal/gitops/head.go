@@ -0,0 +1,148 @@
+package gitops
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"git-genius/internal/config"
+	"git-genius/internal/conflict"
+	"git-genius/internal/system"
+	"git-genius/internal/ui"
+)
+
+// HeadState describes what HEAD really points at in the work tree
+type HeadState struct {
+	Branch   string // checked out branch, empty when detached
+	Commit   string // short commit id, empty on an unborn branch
+	Detached bool
+	Unborn   bool // branch exists in HEAD but has no commits yet
+
+	Rebasing     bool
+	RebaseBranch string // branch being rebased (HEAD is detached meanwhile)
+	Merging      bool
+}
+
+/* ============================================================
+   Detection
+   ============================================================ */
+
+// Head reads HEAD from git instead of trusting the saved config
+func Head() HeadState {
+	var h HeadState
+
+	if name, ok := system.GitQuery("symbolic-ref", "--quiet", "--short", "HEAD"); ok {
+		h.Branch = name
+	} else {
+		h.Detached = true
+	}
+
+	if c, ok := system.GitQuery("rev-parse", "--verify", "--quiet", "--short", "HEAD"); ok {
+		h.Commit = c
+	} else if !h.Detached {
+		h.Unborn = true
+	}
+
+	for _, dir := range []string{"rebase-merge", "rebase-apply"} {
+		p := system.GitPath(dir)
+		if p == "" || !pathExists(p) {
+			continue
+		}
+		h.Rebasing = true
+		if data, err := os.ReadFile(filepath.Join(p, "head-name")); err == nil {
+			h.RebaseBranch = strings.TrimPrefix(strings.TrimSpace(string(data)), "refs/heads/")
+		}
+	}
+
+	if p := system.GitPath("MERGE_HEAD"); p != "" && pathExists(p) {
+		h.Merging = true
+	}
+
+	return h
+}
+
+// String renders HEAD for the context panel
+func (h HeadState) String() string {
+	switch {
+	case h.Rebasing && h.RebaseBranch != "":
+		return fmt.Sprintf("%s (rebase in progress at %s)", h.RebaseBranch, h.Commit)
+	case h.Rebasing:
+		return fmt.Sprintf("detached at %s (rebase in progress)", h.Commit)
+	case h.Detached:
+		return "detached at " + h.Commit
+	case h.Unborn:
+		return h.Branch + " (no commits yet)"
+	case h.Merging:
+		return h.Branch + " (merge in progress)"
+	}
+	return h.Branch
+}
+
+/* ============================================================
+   Guards
+   ============================================================ */
+
+// EnsureBranch checks that HEAD is on a branch that can be pushed to or
+// pulled into, and reconciles it with the configured default branch.
+// It returns the branch to operate on, or false when the action must stop.
+func EnsureBranch(action string) (string, bool) {
+	h := Head()
+
+	if op := conflict.InProgress(); op != conflict.None {
+		ui.Error("A " + string(op) + " is in progress — finish or abort it before you " + action)
+		if !ui.Batch && ui.Confirm("Open the conflict assistant now?") && conflict.Resolve() {
+			return EnsureBranch(action)
+		}
+		return "", false
+	}
+
+	switch {
+	case h.Detached:
+		ui.Error("HEAD is detached at " + h.Commit + " — switch to a branch before you " + action)
+		return "", false
+	}
+
+	cfg := config.Load()
+	if h.Branch == cfg.Branch {
+		return h.Branch, true
+	}
+
+	if ui.Batch {
+		ui.Error(fmt.Sprintf(
+			"Checked out branch is '%s' but the configured default is '%s' — switch branches or change the default in the menu",
+			h.Branch, cfg.Branch,
+		))
+		return "", false
+	}
+
+	ui.Warn(fmt.Sprintf(
+		"Checked out branch is '%s' but the configured default is '%s'",
+		h.Branch, cfg.Branch,
+	))
+	fmt.Println("1) Continue on " + h.Branch)
+	fmt.Println("2) Make " + h.Branch + " the default branch and continue")
+	fmt.Println("3) Cancel")
+
+	switch ui.Input("Select option") {
+	case "1":
+		return h.Branch, true
+	case "2":
+		cfg.Branch = h.Branch
+		config.Save(cfg)
+		ui.Success("Default branch set to: " + h.Branch)
+		return h.Branch, true
+	}
+
+	ui.Warn("Cancelled")
+	return "", false
+}
+
+/* ============================================================
+   Helpers
+   ============================================================ */
+
+func pathExists(p string) bool {
+	_, err := os.Stat(p)
+	return err == nil
+}
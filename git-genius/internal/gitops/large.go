@@ -0,0 +1,279 @@
+package gitops
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"git-genius/internal/config"
+	"git-genius/internal/gitignore"
+	"git-genius/internal/system"
+	"git-genius/internal/ui"
+)
+
+// allowLargeEnv skips the large file guard for one run (GENIUS_ALLOW_LARGE=1)
+const allowLargeEnv = "GENIUS_ALLOW_LARGE"
+
+// Blob is a file (staged or in history) with its size in bytes
+type Blob struct {
+	Path string
+	Size int64
+}
+
+/* ============================================================
+   Detection
+   ============================================================ */
+
+// StagedLargeFiles returns staged files whose blob exceeds limit bytes.
+// Files already stored through LFS are staged as small pointers.
+func StagedLargeFiles(limit int64) ([]Blob, error) {
+	out, err := system.GitOutput("diff", "--cached", "--name-only", "--diff-filter=AMR", "-z")
+	if err != nil || out == "" {
+		return nil, err
+	}
+
+	paths := strings.Split(strings.TrimRight(out, "\x00"), "\x00")
+	var in strings.Builder
+	for _, p := range paths {
+		in.WriteString(":" + p + "\n")
+	}
+
+	sizes, err := system.GitInput(in.String(), "cat-file", "--batch-check=%(objectsize)")
+	if err != nil {
+		return nil, err
+	}
+
+	var big []Blob
+	for i, l := range strings.Split(sizes, "\n") {
+		n, err := strconv.ParseInt(strings.TrimSpace(l), 10, 64)
+		if err == nil && i < len(paths) && n > limit {
+			big = append(big, Blob{Path: paths[i], Size: n})
+		}
+	}
+	return big, nil
+}
+
+// LargeBlobs returns the n biggest blobs above limit in the whole history
+func LargeBlobs(limit int64, n int) ([]Blob, error) {
+	objects, err := system.GitOutput("rev-list", "--objects", "--all")
+	if err != nil || objects == "" {
+		return nil, err
+	}
+
+	out, err := system.GitInput(objects+"\n", "cat-file",
+		"--batch-check=%(objecttype) %(objectsize) %(rest)")
+	if err != nil {
+		return nil, err
+	}
+
+	var blobs []Blob
+	for _, l := range strings.Split(out, "\n") {
+		f := strings.SplitN(l, " ", 3)
+		if len(f) < 3 || f[0] != "blob" {
+			continue
+		}
+		size, err := strconv.ParseInt(f[1], 10, 64)
+		if err == nil && size > limit {
+			blobs = append(blobs, Blob{Path: f[2], Size: size})
+		}
+	}
+
+	sort.Slice(blobs, func(i, j int) bool { return blobs[i].Size > blobs[j].Size })
+	if len(blobs) > n {
+		blobs = blobs[:n]
+	}
+	return blobs, nil
+}
+
+// MaxFileBytes returns the configured size limit, or -1 when disabled
+func MaxFileBytes(cfg config.Config) int64 {
+	if cfg.MaxFileMB < 0 {
+		return -1
+	}
+	return int64(cfg.MaxFileMB) << 20
+}
+
+// HumanSize formats a byte count as KB/MB/GB
+func HumanSize(n int64) string {
+	switch {
+	case n >= 1<<30:
+		return fmt.Sprintf("%.1f GB", float64(n)/(1<<30))
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%d B", n)
+}
+
+/* ============================================================
+   Guard
+   ============================================================ */
+
+/*
+guardLargeFiles stops a commit containing files above the configured
+size. They can be moved to Git LFS, ignored, or committed anyway.
+It returns true to go ahead.
+*/
+func guardLargeFiles() bool {
+	limit := MaxFileBytes(config.Load())
+	if limit < 0 {
+		return true
+	}
+
+	big, err := StagedLargeFiles(limit)
+	if err != nil {
+		ui.Warn("Large file check failed — continuing without it (see error.log)")
+		return true
+	}
+	if len(big) == 0 {
+		return true
+	}
+
+	ui.Warn(fmt.Sprintf("%d staged file(s) larger than %s:", len(big), HumanSize(limit)))
+	for _, b := range big {
+		fmt.Printf("  %s%s%s  %s\n", ui.Yellow, b.Path, ui.Reset, HumanSize(b.Size))
+	}
+
+	if os.Getenv(allowLargeEnv) == "1" {
+		ui.Warn(allowLargeEnv + "=1 is set — committing anyway")
+		return true
+	}
+
+	for {
+		fmt.Println()
+		fmt.Println("1) Track them with Git LFS")
+		fmt.Println("2) Add them to .gitignore and unstage")
+		fmt.Println("3) Commit anyway")
+		fmt.Println("0) Stop (changes stay staged)")
+
+		switch ui.Input("Select option") {
+		case "1":
+			if trackWithLFS(big) {
+				return true
+			}
+		case "2":
+			ignoreFiles(big)
+			_, clean := system.GitQuery("diff", "--cached", "--quiet")
+			if clean {
+				ui.Warn("Nothing left to commit")
+			}
+			return !clean
+		case "3":
+			return true
+		case "0", "":
+			ui.Warn("Commit stopped — changes are left staged")
+			return false
+		default:
+			ui.Error("Invalid option, please try again")
+		}
+	}
+}
+
+/* ============================================================
+   Git LFS
+   ============================================================ */
+
+// LFSInstalled reports whether the git-lfs extension is available
+func LFSInstalled() bool {
+	_, ok := system.GitQuery("lfs", "version")
+	return ok
+}
+
+// LFSPatterns returns the patterns tracked through LFS in .gitattributes
+func LFSPatterns() []string {
+	data, err := os.ReadFile(filepath.Join(system.RepoRoot(), ".gitattributes"))
+	if err != nil {
+		return nil
+	}
+
+	var patterns []string
+	for _, l := range strings.Split(string(data), "\n") {
+		f := strings.Fields(l)
+		if len(f) > 1 && !strings.HasPrefix(f[0], "#") && slices.Contains(f[1:], "filter=lfs") {
+			patterns = append(patterns, f[0])
+		}
+	}
+	return patterns
+}
+
+/*
+trackWithLFS installs the LFS hooks, tracks the files (by extension when
+the user agrees) and re-stages them so the index holds LFS pointers.
+*/
+func trackWithLFS(big []Blob) bool {
+	if !LFSInstalled() {
+		ui.Error("Git LFS is not installed (Termux: pkg install git-lfs)")
+		return false
+	}
+
+	if err := system.RunGit("lfs", "install", "--local"); err != nil {
+		ui.Error("git lfs install failed (see error.log)")
+		return false
+	}
+
+	byExt := ui.Confirm("Track by file extension (e.g. *.apk) instead of exact paths?")
+
+	seen := map[string]bool{}
+	var patterns, paths []string
+	for _, b := range big {
+		p := b.Path
+		if ext := path.Ext(b.Path); byExt && ext != "" {
+			p = "*" + ext
+		}
+		if !seen[p] {
+			seen[p] = true
+			patterns = append(patterns, p)
+		}
+		paths = append(paths, ":(top)"+b.Path)
+	}
+
+	// Run from the root so patterns are root-relative
+	args := append([]string{"-C", system.RepoRoot(), "lfs", "track", "--"}, patterns...)
+	if err := system.RunGit(args...); err != nil {
+		ui.Error("git lfs track failed (see error.log)")
+		return false
+	}
+
+	// Re-add through the LFS clean filter
+	if err := unstagePaths(paths); err != nil {
+		ui.Error("Failed to re-stage files")
+		return false
+	}
+	if err := system.RunGit(append([]string{"add", "--", ":(top).gitattributes"}, paths...)...); err != nil {
+		ui.Error("Failed to re-stage files")
+		return false
+	}
+
+	ui.Success("Tracking with LFS: " + strings.Join(patterns, ", "))
+	return true
+}
+
+// ignoreFiles appends the files to .gitignore and leaves them out of the commit
+func ignoreFiles(big []Blob) {
+	var entries, paths []string
+	for _, b := range big {
+		entries = append(entries, "/"+b.Path)
+		paths = append(paths, ":(top)"+b.Path)
+	}
+
+	if _, err := gitignore.AddEntries(system.RepoRoot(), entries); err != nil {
+		system.LogError("update .gitignore", err)
+		ui.Error("Could not update .gitignore")
+		return
+	}
+
+	if err := unstagePaths(paths); err != nil {
+		ui.Error("Failed to unstage files")
+		return
+	}
+	if err := system.RunGit("add", "--", ":(top).gitignore"); err != nil {
+		ui.Warn("Could not stage .gitignore")
+	}
+	ui.Success(fmt.Sprintf("Ignored %d file(s) — they stay on disk", len(entries)))
+}
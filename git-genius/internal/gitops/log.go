@@ -0,0 +1,326 @@
+package gitops
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"git-genius/internal/system"
+	"git-genius/internal/ui"
+)
+
+// LogFilter narrows the commits shown by the log browser
+type LogFilter struct {
+	Author string
+	Since  string // anything git understands, e.g. "2 weeks ago"
+	Until  string
+	Grep   string // message search (case-insensitive)
+	Path   string
+}
+
+// String summarises active filters for the header
+func (f LogFilter) String() string {
+	var parts []string
+	add := func(k, v string) {
+		if v != "" {
+			parts = append(parts, k+"="+v)
+		}
+	}
+	add("author", f.Author)
+	add("since", f.Since)
+	add("until", f.Until)
+	add("message", f.Grep)
+	add("path", f.Path)
+
+	if len(parts) == 0 {
+		return "none"
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (f LogFilter) args() []string {
+	var args []string
+	if f.Author != "" {
+		args = append(args, "--author="+f.Author)
+	}
+	if f.Since != "" {
+		args = append(args, "--since="+f.Since)
+	}
+	if f.Until != "" {
+		args = append(args, "--until="+f.Until)
+	}
+	if f.Grep != "" {
+		args = append(args, "-i", "--grep="+f.Grep)
+	}
+	if f.Path != "" {
+		args = append(args, "--", f.Path)
+	}
+	return args
+}
+
+// logLine is one line of log output; Hash is empty for graph-only lines
+type logLine struct {
+	Graph string
+	Hash  string
+	Text  string
+}
+
+const logPageSize = 15
+
+/* ============================================================
+   Log Browser
+   ============================================================ */
+
+// LogBrowser shows a paginated, filterable commit log
+func LogBrowser() {
+	if !system.EnsureGitRepo() {
+		return
+	}
+	if Head().Unborn {
+		ui.Info("No commits yet")
+		return
+	}
+
+	var filter LogFilter
+	graph := true
+	page := 0
+
+	for {
+		lines := logPage(filter, graph, page)
+
+		fmt.Println()
+		ui.Header(fmt.Sprintf("Commit Log — page %d", page+1))
+		fmt.Println("Filters : " + filter.String())
+		fmt.Println()
+
+		var hashes []string
+		for _, l := range lines {
+			if l.Hash == "" {
+				fmt.Println("    " + l.Graph)
+				continue
+			}
+			hashes = append(hashes, l.Hash)
+			fmt.Printf("%3d) %s%s\n", len(hashes), l.Graph, l.Text)
+		}
+		if len(hashes) == 0 {
+			ui.Info("No commits on this page")
+		}
+
+		fmt.Println()
+		fmt.Println(" n) Next page   p) Previous page   f) Filters   g) Toggle graph   0) Back")
+
+		choice := ui.Input("Select commit number or action")
+		switch choice {
+		case "n":
+			if len(hashes) == logPageSize {
+				page++
+			} else {
+				ui.Info("Last page")
+			}
+		case "p":
+			if page > 0 {
+				page--
+			}
+		case "f":
+			filter = editFilter(filter)
+			page = 0
+		case "g":
+			graph = !graph
+		case "0", "":
+			return
+		default:
+			n, err := strconv.Atoi(choice)
+			if err != nil || n < 1 || n > len(hashes) {
+				ui.Error("Invalid option, please try again")
+				continue
+			}
+			CommitDetail(hashes[n-1])
+		}
+	}
+}
+
+// logPage reads one page of commits (plus graph lines when enabled)
+func logPage(f LogFilter, graph bool, page int) []logLine {
+	args := []string{
+		"log", "--decorate",
+		"--skip=" + strconv.Itoa(page*logPageSize),
+		"--max-count=" + strconv.Itoa(logPageSize),
+		"--format=\x1e%H\x1f" + ui.Yellow + "%h" + ui.Reset + " %s " + ui.Cyan + "(%an, %ar)" + ui.Reset + ui.Green + "%d" + ui.Reset,
+	}
+	if graph {
+		args = append(args, "--graph")
+	}
+	args = append(args, f.args()...)
+
+	out, err := system.GitOutput(args...)
+	if err != nil || out == "" {
+		return nil
+	}
+
+	var lines []logLine
+	for _, l := range strings.Split(out, "\n") {
+		g, rest, ok := strings.Cut(l, "\x1e")
+		if !ok {
+			lines = append(lines, logLine{Graph: l})
+			continue
+		}
+		hash, text, _ := strings.Cut(rest, "\x1f")
+		lines = append(lines, logLine{Graph: g, Hash: hash, Text: text})
+	}
+	return lines
+}
+
+func editFilter(f LogFilter) LogFilter {
+	ui.Info("Enter a value to set, '-' to clear, empty to keep")
+
+	edit := func(label string, v *string) {
+		in := ui.Input(label + " [" + *v + "]")
+		switch in {
+		case "":
+		case "-":
+			*v = ""
+		default:
+			*v = in
+		}
+	}
+
+	edit("Author", &f.Author)
+	edit("Since (e.g. 2 weeks ago, 2024-01-31)", &f.Since)
+	edit("Until", &f.Until)
+	edit("Message contains", &f.Grep)
+	edit("Path", &f.Path)
+	return f
+}
+
+/* ============================================================
+   Commit Detail
+   ============================================================ */
+
+// CommitDetail shows a commit with its diffstat and offers actions on it
+func CommitDetail(hash string) {
+	for {
+		fmt.Println()
+		if err := system.RunGit("--no-pager", "show", "--stat", "--format=fuller", hash); err != nil {
+			ui.Error("Cannot show commit " + hash)
+			return
+		}
+
+		fmt.Println()
+		fmt.Println("1) Full diff")
+		fmt.Println("2) Checkout (detached HEAD)")
+		fmt.Println("3) Cherry-pick onto current branch")
+		fmt.Println("4) Revert")
+		fmt.Println("5) Create branch here")
+		fmt.Println("6) Create tag here")
+		fmt.Println("0) Back")
+
+		switch ui.Input("Select option") {
+		case "1":
+			ViewPatch("show", "--format=", "--patch", "-M", hash)
+			ui.Pause()
+		case "2":
+			checkoutCommit(hash)
+			return
+		case "3":
+			applyCommit("cherry-pick", hash)
+		case "4":
+			applyCommit("revert", hash)
+		case "5":
+			branchAt(hash)
+		case "6":
+			tagAt(hash)
+		case "0", "":
+			return
+		default:
+			ui.Error("Invalid option, please try again")
+		}
+	}
+}
+
+func checkoutCommit(hash string) {
+	ui.Warn("This detaches HEAD — new commits will not belong to any branch")
+	if !ui.Confirm("Checkout " + short(hash) + "?") {
+		return
+	}
+	recordUndo("checkout " + short(hash))
+	if err := system.RunGit("checkout", "--detach", hash); err != nil {
+		ui.Error("Checkout failed (uncommitted changes may conflict)")
+		return
+	}
+	ui.Success("HEAD is now at " + short(hash))
+}
+
+// applyCommit cherry-picks or reverts a single commit
+func applyCommit(op, hash string) {
+	if !ui.Confirm(strings.ToUpper(op[:1]) + op[1:] + " " + short(hash) + " on " + Head().String() + "?") {
+		return
+	}
+
+	args := []string{op, hash}
+	if op == "revert" {
+		args = []string{op, "--no-edit", hash}
+	}
+	runSequencer(op, args)
+}
+
+func branchAt(hash string) {
+	name := ui.Input("New branch name")
+	if name == "" {
+		ui.Error("Branch name cannot be empty")
+		return
+	}
+	if BranchExists(name) {
+		ui.Error("Branch already exists: " + name)
+		return
+	}
+
+	recordUndo("create branch " + name)
+	if err := system.RunGit("branch", name, hash); err != nil {
+		ui.Error("Failed to create branch")
+		return
+	}
+	ui.Success("Created branch " + name + " at " + short(hash))
+
+	if ui.Confirm("Switch to " + name + " now?") {
+		SwitchBranch(name)
+	}
+}
+
+func tagAt(hash string) {
+	name := ui.Input("Tag name (e.g. v1.2.0)")
+	if name == "" {
+		ui.Error("Tag name cannot be empty")
+		return
+	}
+
+	msg := ui.Input("Tag message [" + name + "]")
+	if msg == "" {
+		msg = name
+	}
+
+	recordTagUndo("tag "+name, name)
+	if err := system.RunGit("tag", "-a", name, "-m", msg, hash); err != nil {
+		ui.Error("Failed to create tag")
+		return
+	}
+	ui.Success("Created tag " + name + " at " + short(hash))
+
+	if ui.Confirm("Push tag to " + CurrentRemote() + "?") {
+		if err := system.RunGit("push", CurrentRemote(), "refs/tags/"+name); err != nil {
+			ui.Error("Failed to push tag")
+			return
+		}
+		ui.Success("Tag pushed")
+	}
+}
+
+/* ============================================================
+   Helpers
+   ============================================================ */
+
+func short(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
@@ -0,0 +1,223 @@
+package gitops
+
+import (
+	"fmt"
+	"strings"
+
+	"git-genius/internal/conflict"
+	"git-genius/internal/system"
+	"git-genius/internal/ui"
+)
+
+/* ============================================================
+   Cherry-pick
+   ============================================================ */
+
+// CherryPick applies commits from elsewhere onto the current branch
+func CherryPick() {
+	if !system.EnsureGitRepo() {
+		return
+	}
+
+	fmt.Println()
+	ui.Header("Cherry-pick onto " + Head().String())
+	fmt.Println("1) Single commit")
+	fmt.Println("2) Range of commits")
+	fmt.Println("3) Pick from another branch")
+	fmt.Println("0) Back")
+
+	var revs []string
+	switch ui.Input("Select option") {
+	case "1":
+		revs = askCommit("Commit to cherry-pick")
+	case "2":
+		revs = askRange(true)
+	case "3":
+		revs = pickFromBranch()
+	default:
+		return
+	}
+	if len(revs) == 0 {
+		return
+	}
+
+	args := []string{"cherry-pick"}
+	if ui.Confirm("Record the original commit id in the message (-x, useful for backports)?") {
+		args = append(args, "-x")
+	}
+	runSequencer("cherry-pick", append(args, revs...))
+}
+
+// pickFromBranch lists commits of another branch missing here and lets
+// the user choose which to apply (oldest first)
+func pickFromBranch() []string {
+	b, ok := pickBranch("Source branch", Branches())
+	if !ok {
+		return nil
+	}
+
+	// --cherry-pick hides commits whose change is already applied here
+	out, err := system.GitOutput("log", "--reverse", "--right-only", "--cherry-pick",
+		"--no-merges", "--format=%H%x1f%h%x1f%s%x1f%an, %ar", "HEAD..."+b.Name)
+	if err != nil || out == "" {
+		ui.Info("No commits on " + b.Name + " that are missing here")
+		return nil
+	}
+
+	var hashes, items []string
+	for _, l := range strings.Split(out, "\n") {
+		f := strings.Split(l, "\x1f")
+		if len(f) < 4 {
+			continue
+		}
+		hashes = append(hashes, f[0])
+		items = append(items, ui.Yellow+f[1]+ui.Reset+" "+f[2]+" "+ui.Cyan+"("+f[3]+")"+ui.Reset)
+	}
+
+	ui.Info("Commits on " + b.Name + " missing here (oldest first)")
+	var revs []string
+	for _, i := range ui.MultiSelect("Commits to apply", items) {
+		revs = append(revs, hashes[i])
+	}
+	return revs
+}
+
+/* ============================================================
+   Revert
+   ============================================================ */
+
+// Revert creates commits undoing one commit or a range
+func Revert() {
+	if !system.EnsureGitRepo() {
+		return
+	}
+
+	fmt.Println()
+	ui.Header("Revert on " + Head().String())
+	fmt.Println("1) Single commit")
+	fmt.Println("2) Range of commits")
+	fmt.Println("0) Back")
+
+	var revs []string
+	switch ui.Input("Select option") {
+	case "1":
+		revs = askCommit("Commit to revert")
+	case "2":
+		revs = askRange(false)
+	default:
+		return
+	}
+	if len(revs) == 0 {
+		return
+	}
+
+	runSequencer("revert", append([]string{"revert", "--no-edit"}, revs...))
+}
+
+/* ============================================================
+   Shared flow
+   ============================================================ */
+
+/*
+runSequencer runs a cherry-pick or revert, routes conflicts through the
+conflict assistant and prints the commits it created.
+*/
+func runSequencer(op string, args []string) {
+	if op := conflict.InProgress(); op != conflict.None {
+		ui.Error("A " + string(op) + " is already in progress — finish it first")
+		return
+	}
+
+	before, _ := system.GitQuery("rev-parse", "HEAD")
+
+	recordUndo(op)
+	err := system.RunGit(append([]string{"-c", "core.editor=true"}, args...)...)
+	if err != nil {
+		if conflict.InProgress() == conflict.None {
+			ui.Error(op + " failed (see error.log)")
+			return
+		}
+		ui.Warn("Stopped while applying — opening the conflict assistant")
+		if !conflict.Resolve() {
+			return
+		}
+	}
+
+	summarizeSince(before)
+}
+
+// summarizeSince prints commits created on top of before
+func summarizeSince(before string) {
+	rng := "HEAD"
+	if before != "" {
+		rng = before + "..HEAD"
+	}
+
+	out, _ := system.GitQuery("log", "--reverse", "--format=%h %s", rng)
+	if out == "" {
+		ui.Warn("No new commits were created")
+		return
+	}
+
+	lines := strings.Split(out, "\n")
+	ui.Success(fmt.Sprintf("%d new commit(s):", len(lines)))
+	for _, l := range lines {
+		fmt.Println("  " + l)
+	}
+}
+
+// askCommit reads and verifies a single commit reference
+func askCommit(label string) []string {
+	rev := ui.Input(label + " (hash, tag or branch)")
+	if rev == "" {
+		return nil
+	}
+	hash, ok := system.GitQuery("rev-parse", "--verify", "--quiet", rev+"^{commit}")
+	if !ok {
+		ui.Error("Unknown commit: " + rev)
+		return nil
+	}
+	return []string{hash}
+}
+
+// askRange reads an inclusive range of commits from oldest to newest
+func askRange(oldestFirst bool) []string {
+	from := ui.Input("Oldest commit of the range (included)")
+	to := ui.Input("Newest commit of the range [HEAD]")
+	if to == "" {
+		to = "HEAD"
+	}
+	if from == "" {
+		ui.Error("The oldest commit is required")
+		return nil
+	}
+
+	for _, r := range []string{from, to} {
+		if _, ok := system.GitQuery("rev-parse", "--verify", "--quiet", r+"^{commit}"); !ok {
+			ui.Error("Unknown commit: " + r)
+			return nil
+		}
+	}
+
+	args := []string{"rev-list", "--no-merges"}
+	if oldestFirst {
+		args = append(args, "--reverse")
+	}
+
+	// A root commit has no parent, so include it explicitly
+	if _, ok := system.GitQuery("rev-parse", "--verify", "--quiet", from+"^"); ok {
+		args = append(args, from+"^.."+to)
+	} else {
+		args = append(args, to)
+	}
+
+	out, _ := system.GitQuery(args...)
+	if out == "" {
+		ui.Error("The range is empty")
+		return nil
+	}
+
+	revs := strings.Split(out, "\n")
+	ui.Info(fmt.Sprintf("%d commit(s) in range", len(revs)))
+	return revs
+}
@@ -0,0 +1,174 @@
+package gitops
+
+import (
+	"fmt"
+	"strings"
+
+	"git-genius/internal/config"
+	"git-genius/internal/conflict"
+	"git-genius/internal/system"
+	"git-genius/internal/ui"
+)
+
+// Pull strategies accepted by Pull and config.PullStrategy
+const (
+	PullMerge  = "merge"
+	PullRebase = "rebase"
+	PullFFOnly = "ff-only"
+)
+
+const autostashMessage = "genius-autostash"
+
+// ValidPullStrategy reports whether s names a supported pull strategy
+func ValidPullStrategy(s string) bool {
+	return s == PullMerge || s == PullRebase || s == PullFFOnly
+}
+
+/*
+Pull fetches the current branch from the default remote and integrates it
+with the given strategy. An empty strategy uses the configured one.
+Local changes are stashed for the duration of the pull and restored after.
+*/
+func Pull(strategy string) {
+	if !system.EnsureGitRepo() {
+		return
+	}
+
+	branch, ok := EnsureBranch("pull")
+	if !ok {
+		return
+	}
+
+	cfg := config.Load()
+	if strategy == "" {
+		strategy = cfg.PullStrategy
+	}
+	if !ValidPullStrategy(strategy) {
+		ui.Error("Unknown pull strategy: " + strategy + " (use merge, rebase or ff-only)")
+		return
+	}
+
+	ui.Info("Fetching latest changes...")
+	if err := system.RunGit("fetch", cfg.Remote, branch); err != nil {
+		ui.Error("Fetch failed")
+		return
+	}
+
+	upstream := cfg.Remote + "/" + branch
+	if !showIncoming(upstream) {
+		return
+	}
+
+	if !ui.Confirm("Apply incoming commits using " + strategy + "?") {
+		ui.Warn("Pull cancelled")
+		return
+	}
+
+	stashed := false
+	if IsDirty() {
+		if !cfg.Autostash && !ui.Confirm("You have local changes. Stash them during the pull?") {
+			ui.Error("Commit or stash your changes before pulling")
+			return
+		}
+		ui.Info("Stashing local changes...")
+		if err := StashPush(autostashMessage, true); err != nil {
+			ui.Error("Failed to stash local changes")
+			return
+		}
+		stashed = true
+	}
+
+	recordUndo("pull (" + strategy + ")")
+	if err := integrate(strategy, upstream); err != nil {
+		resolved := false
+		switch {
+		case strategy == PullFFOnly:
+			ui.Error("Cannot fast-forward — your branch has diverged (try merge or rebase)")
+		case conflict.InProgress() != conflict.None:
+			ui.Warn("Conflict detected while applying " + upstream)
+			resolved = conflict.Resolve()
+		default:
+			ui.Error("Failed to apply " + upstream + " (see error.log)")
+		}
+		if !resolved {
+			// Popping now would mix local edits into the unfinished merge
+			// or rebase; otherwise the tree is back to its pre-pull state
+			if stashed && conflict.InProgress() != conflict.None {
+				ui.Warn("Your local changes are kept in the stash (" + autostashMessage + ")")
+			} else if stashed {
+				restoreStash()
+			}
+			return
+		}
+	}
+
+	if stashed {
+		restoreStash()
+	}
+
+	ui.Success("Pulled latest changes (" + strategy + ")")
+}
+
+/* ============================================================
+   Helpers
+   ============================================================ */
+
+// restoreStash pops the autostash created by Pull
+func restoreStash() {
+	ui.Info("Restoring local changes...")
+	if err := system.RunGit("stash", "pop"); err != nil {
+		ui.Warn("Restoring local changes conflicted — they remain in the stash")
+	}
+}
+
+// integrate applies upstream onto the current branch with the given strategy
+func integrate(strategy, upstream string) error {
+	switch strategy {
+	case PullRebase:
+		ui.Info("Rebasing onto " + upstream + "...")
+		return system.RunGit("rebase", upstream)
+	case PullFFOnly:
+		ui.Info("Fast-forwarding to " + upstream + "...")
+		return system.RunGit("merge", "--ff-only", upstream)
+	}
+	ui.Info("Merging changes...")
+	return system.RunGit("merge", "--no-edit", upstream)
+}
+
+// showIncoming prints the commits about to be applied.
+// It returns false when there is nothing to pull.
+func showIncoming(upstream string) bool {
+	rng := "HEAD.." + upstream
+	if Head().Unborn {
+		rng = upstream
+	}
+
+	out, err := system.GitOutput("log", "--no-decorate", "--format=%h %s (%an, %ar)", rng)
+	if err != nil {
+		ui.Error("Unable to read incoming commits from " + upstream)
+		return false
+	}
+
+	if out == "" {
+		ui.Success("Already up to date with " + upstream)
+		return false
+	}
+
+	lines := strings.Split(out, "\n")
+	ui.Info(fmt.Sprintf("%d incoming commit(s) from %s:", len(lines), upstream))
+
+	const max = 15
+	for i, l := range lines {
+		if i == max {
+			fmt.Printf("  ... and %d more\n", len(lines)-max)
+			break
+		}
+		fmt.Println("  " + l)
+	}
+
+	if ahead, ok := system.GitQuery("rev-list", "--count", upstream+"..HEAD"); ok && ahead != "0" {
+		ui.Info(ahead + " local commit(s) not yet on " + upstream)
+	}
+
+	return true
+}
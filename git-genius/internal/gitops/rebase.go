@@ -0,0 +1,377 @@
+package gitops
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"git-genius/internal/conflict"
+	"git-genius/internal/system"
+	"git-genius/internal/ui"
+)
+
+// Commit is a commit listed for rewriting
+type Commit struct {
+	Hash    string
+	Short   string
+	Subject string
+}
+
+// todoItem is one line of a rebase todo list
+type todoItem struct {
+	Action string // pick, reword, edit, squash, fixup, drop, exec
+	Hash   string
+	Arg    string // subject for commits, command for exec
+}
+
+func (t todoItem) String() string {
+	if t.Action == "exec" {
+		return "exec " + t.Arg
+	}
+	return t.Action + " " + t.Hash + " " + t.Arg
+}
+
+/* ============================================================
+   Queries
+   ============================================================ */
+
+/*
+UnpushedCommits returns commits on the current branch that are not on
+its upstream (or on any remote when there is no upstream), newest first.
+*/
+func UnpushedCommits() []Commit {
+	rng := []string{"HEAD", "--not", "--remotes"}
+	if up, ok := system.GitQuery("rev-parse", "--abbrev-ref", "@{upstream}"); ok {
+		rng = []string{up + "..HEAD"}
+	}
+
+	args := append([]string{"log", "--format=%H%x1f%h%x1f%s"}, rng...)
+	out, ok := system.GitQuery(args...)
+	if !ok || out == "" {
+		return nil
+	}
+
+	var list []Commit
+	for _, l := range strings.Split(out, "\n") {
+		f := strings.Split(l, "\x1f")
+		if len(f) == 3 {
+			list = append(list, Commit{f[0], f[1], f[2]})
+		}
+	}
+	return list
+}
+
+// guardRewrite warns before rewriting commits that exist on a remote
+func guardRewrite(hashes ...string) bool {
+	for _, h := range hashes {
+		if onRemote(h) {
+			ui.Warn("Commit " + short(h) + " already exists on a remote")
+			ui.Warn("Rewriting it needs a force push and breaks clones of collaborators")
+			return ui.Confirm("Rewrite published history anyway?")
+		}
+	}
+	return true
+}
+
+/* ============================================================
+   Interactive rebase helper
+   ============================================================ */
+
+// planStep is a commit in the rebase plan with the action chosen for it
+type planStep struct {
+	Commit
+	Action  string
+	Message string // new message for reword
+}
+
+// InteractiveRebase lets the user squash, fixup, reorder, drop, edit or
+// reword unpushed commits through the menu, then applies the plan.
+func InteractiveRebase() {
+	if !system.EnsureGitRepo() {
+		return
+	}
+	if conflict.InProgress() == conflict.Rebase {
+		ui.Warn("A rebase is already in progress")
+		handleStoppedRebase()
+		return
+	}
+
+	list := UnpushedCommits()
+	if len(list) < 1 {
+		ui.Info("No unpushed commits to rebase")
+		return
+	}
+
+	// Oldest first, like the rebase todo list
+	plan := make([]planStep, len(list))
+	for i, c := range list {
+		plan[len(list)-1-i] = planStep{Commit: c, Action: "pick"}
+	}
+
+	base := rebaseBase(plan[0].Hash)
+	if hasMerges(base) {
+		ui.Error("Merge commits in the range — interactive rebase is not supported here")
+		return
+	}
+
+	for {
+		fmt.Println()
+		ui.Header("Interactive Rebase — oldest first")
+		for i, st := range plan {
+			fmt.Printf("%2d) %s%-6s%s %s%s%s %s\n",
+				i+1, actionColor(st.Action), st.Action, ui.Reset, ui.Yellow, st.Short, ui.Reset, st.Subject)
+			if st.Message != "" {
+				fmt.Println("        → " + firstLine(st.Message))
+			}
+		}
+
+		fmt.Println()
+		fmt.Println(" p N pick   s N squash   f N fixup   d N drop")
+		fmt.Println(" e N edit   r N reword   u N move up   m N move down")
+		fmt.Println(" a) Apply   0) Cancel")
+
+		in := strings.Fields(ui.Input("Command"))
+		if len(in) == 0 {
+			continue
+		}
+
+		switch in[0] {
+		case "0":
+			ui.Warn("Rebase cancelled")
+			return
+		case "a":
+			applyPlan(base, plan)
+			return
+		}
+
+		if len(in) != 2 {
+			ui.Error("Use a command followed by a number, e.g. s 2")
+			continue
+		}
+		n, err := strconv.Atoi(in[1])
+		if err != nil || n < 1 || n > len(plan) {
+			ui.Error("Invalid commit number")
+			continue
+		}
+		i := n - 1
+
+		switch in[0] {
+		case "p", "s", "f", "d", "e":
+			plan[i].Action = map[string]string{
+				"p": "pick", "s": "squash", "f": "fixup", "d": "drop", "e": "edit",
+			}[in[0]]
+			plan[i].Message = ""
+		case "r":
+			msg, changed, err := newMessage(plan[i].Hash)
+			if err != nil {
+				ui.Error("Invalid commit message: " + err.Error())
+			} else if changed {
+				plan[i].Action = "reword"
+				plan[i].Message = msg
+			}
+		case "u":
+			if i > 0 {
+				plan[i-1], plan[i] = plan[i], plan[i-1]
+			}
+		case "m":
+			if i < len(plan)-1 {
+				plan[i+1], plan[i] = plan[i], plan[i+1]
+			}
+		default:
+			ui.Error("Unknown command: " + in[0])
+		}
+	}
+}
+
+// applyPlan turns the plan into a todo list and runs the rebase
+func applyPlan(base string, plan []planStep) {
+	first := ""
+	for _, st := range plan {
+		if st.Action != "drop" {
+			first = st.Action
+			break
+		}
+	}
+	if first == "squash" || first == "fixup" {
+		ui.Error("The first kept commit cannot be squashed — there is nothing before it")
+		return
+	}
+
+	var todo []todoItem
+	var files []string
+	defer func() { removeWhenDone(files) }()
+
+	for _, st := range plan {
+		if st.Action != "reword" {
+			todo = append(todo, todoItem{Action: st.Action, Hash: st.Hash, Arg: st.Subject})
+			continue
+		}
+
+		f, err := messageFile(st.Message)
+		if err != nil {
+			ui.Error("Failed to prepare reword")
+			return
+		}
+		files = append(files, f)
+		todo = append(todo, todoItem{Action: "pick", Hash: st.Hash, Arg: st.Subject}, amendExec(f))
+	}
+
+	recordUndo("interactive rebase")
+	if runRebase(base, todo) {
+		ui.Success("Rebase completed")
+		_ = system.RunGit("--no-pager", "log", "--oneline", "-n", strconv.Itoa(len(plan)))
+	}
+}
+
+// ContinueRebase resumes or aborts a rebase paused earlier
+func ContinueRebase() {
+	if conflict.InProgress() != conflict.Rebase {
+		ui.Info("No rebase in progress")
+		return
+	}
+	if handleStoppedRebase() {
+		ui.Success("Rebase completed")
+	}
+}
+
+func actionColor(action string) string {
+	switch action {
+	case "drop":
+		return ui.Red
+	case "squash", "fixup":
+		return ui.Magenta
+	case "edit", "reword":
+		return ui.Cyan
+	}
+	return ui.Green
+}
+
+func firstLine(s string) string {
+	l, _, _ := strings.Cut(s, "\n")
+	return l
+}
+
+/* ============================================================
+   Scripted rebase
+   ============================================================ */
+
+/*
+runRebase starts "git rebase -i" onto base and feeds it the given todo
+list through GIT_SEQUENCE_EDITOR, so no editor opens. An empty base
+rebases from the root commit. Conflicts and "edit" stops are handed to
+the user. It returns true once the rebase has finished.
+*/
+func runRebase(base string, items []todoItem) bool {
+	f, err := os.CreateTemp("", "genius-todo-*")
+	if err != nil {
+		system.LogError("creating rebase todo", err)
+		ui.Error("Failed to prepare rebase")
+		return false
+	}
+	defer os.Remove(f.Name())
+
+	for _, it := range items {
+		fmt.Fprintln(f, it)
+	}
+	f.Close()
+
+	args := []string{"-c", "core.editor=true", "rebase", "-i", "--autostash"}
+	if base == "" {
+		args = append(args, "--root")
+	} else {
+		args = append(args, base)
+	}
+
+	env := []string{"GIT_SEQUENCE_EDITOR=cp " + shellQuote(f.Name())}
+	if err := system.RunGitEnv(env, args...); err == nil && conflict.InProgress() == conflict.None {
+		return true
+	}
+
+	return handleStoppedRebase()
+}
+
+// handleStoppedRebase walks the user through conflicts and "edit" stops
+func handleStoppedRebase() bool {
+	for conflict.InProgress() == conflict.Rebase {
+		if conflict.Pending() {
+			if !conflict.Resolve() {
+				return false
+			}
+			continue
+		}
+
+		ui.Info("Rebase paused at " + Head().Commit + " for editing")
+		fmt.Println("1) Amend this commit")
+		fmt.Println("2) Continue rebase")
+		fmt.Println("3) Abort rebase")
+		fmt.Println("4) Leave paused (finish later)")
+
+		switch ui.Input("Select option") {
+		case "1":
+			Amend()
+		case "2":
+			_ = system.RunGit("-c", "core.editor=true", "rebase", "--continue")
+		case "3":
+			if err := system.RunGit("rebase", "--abort"); err == nil {
+				ui.Warn("Rebase aborted")
+			}
+			return false
+		default:
+			ui.Warn("Rebase left paused — continue it from the rewrite menu")
+			return false
+		}
+	}
+	return true
+}
+
+// rebaseBase returns the parent of hash, or "" when hash is a root commit
+func rebaseBase(hash string) string {
+	parent, ok := system.GitQuery("rev-parse", "--verify", "--quiet", hash+"^")
+	if !ok {
+		return ""
+	}
+	return parent
+}
+
+// hasMerges reports whether the range base..HEAD contains merge commits
+func hasMerges(base string) bool {
+	rng := "HEAD"
+	if base != "" {
+		rng = base + "..HEAD"
+	}
+	out, _ := system.GitQuery("rev-list", "--merges", rng)
+	return out != ""
+}
+
+// messageFile writes a commit message for an "exec git commit -F" step
+func messageFile(msg string) (string, error) {
+	f, err := os.CreateTemp("", "genius-msg-*")
+	if err != nil {
+		system.LogError("creating message file", err)
+		return "", err
+	}
+	defer f.Close()
+
+	_, err = f.WriteString(msg)
+	return f.Name(), err
+}
+
+// removeWhenDone deletes message files unless a paused rebase still needs them
+func removeWhenDone(files []string) {
+	if conflict.InProgress() == conflict.Rebase {
+		return
+	}
+	for _, f := range files {
+		os.Remove(f)
+	}
+}
+
+// amendExec is the todo step that replaces the message of the commit just picked
+func amendExec(msgFile string) todoItem {
+	return todoItem{Action: "exec", Arg: "git commit --amend --only --allow-empty -F " + shellQuote(msgFile)}
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
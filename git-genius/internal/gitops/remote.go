@@ -0,0 +1,355 @@
+package gitops
+
+import (
+	"fmt"
+	"strings"
+
+	"git-genius/internal/config"
+	"git-genius/internal/system"
+	"git-genius/internal/ui"
+)
+
+// Remote is a configured git remote with its URLs
+type Remote struct {
+	Name     string
+	FetchURL string
+	PushURLs []string
+}
+
+// String renders a remote with credentials redacted
+func (r Remote) String() string {
+	s := r.Name + "\n    fetch: " + system.Redact(r.FetchURL)
+	for _, u := range r.PushURLs {
+		s += "\n    push : " + system.Redact(u)
+	}
+	return s
+}
+
+/* ============================================================
+   Queries
+   ============================================================ */
+
+// Remotes lists all configured remotes
+func Remotes() []Remote {
+	out, ok := system.GitQuery("remote")
+	if !ok || out == "" {
+		return nil
+	}
+
+	var list []Remote
+	for _, name := range strings.Split(out, "\n") {
+		r := Remote{Name: name}
+		r.FetchURL, _ = system.GitQuery("remote", "get-url", name)
+		if push, ok := system.GitQuery("remote", "get-url", "--push", "--all", name); ok && push != "" {
+			r.PushURLs = strings.Split(push, "\n")
+		}
+		list = append(list, r)
+	}
+	return list
+}
+
+// RemoteExists reports whether a remote with this name is configured
+func RemoteExists(name string) bool {
+	_, ok := system.GitQuery("remote", "get-url", name)
+	return ok
+}
+
+/* ============================================================
+   Remote Manager
+   ============================================================ */
+
+// RemoteManager is the interactive menu for configuring remotes
+func RemoteManager() {
+	if !system.EnsureGitRepo() {
+		return
+	}
+
+	for {
+		cfg := config.Load()
+
+		fmt.Println()
+		ui.Header("Remote Manager")
+		fmt.Println("Default : " + cfg.Remote)
+		if len(cfg.PushRemotes) > 0 {
+			fmt.Println("Mirrors : " + strings.Join(cfg.PushRemotes, ", "))
+		}
+		fmt.Println()
+		fmt.Println("1) List remotes")
+		fmt.Println("2) Add remote")
+		fmt.Println("3) Rename remote")
+		fmt.Println("4) Set URL")
+		fmt.Println("5) Remove remote")
+		fmt.Println("6) Set default remote")
+		fmt.Println("7) Choose mirror remotes for every push")
+		fmt.Println("8) Push current branch to several remotes")
+		fmt.Println("0) Back")
+
+		switch ui.Input("Select option") {
+		case "1":
+			listRemotes()
+		case "2":
+			addRemote()
+		case "3":
+			renameRemote()
+		case "4":
+			setRemoteURL()
+		case "5":
+			removeRemote()
+		case "6":
+			setDefaultRemote()
+		case "7":
+			chooseMirrors()
+		case "8":
+			pushToSelected()
+		case "0":
+			return
+		default:
+			ui.Error("Invalid option, please try again")
+		}
+	}
+}
+
+/*
+PushToRemotes pushes branch to each remote and prints a summary.
+It returns the number of successful pushes.
+*/
+func PushToRemotes(branch string, remotes []string) int {
+	ok := 0
+	for _, r := range remotes {
+		ui.Info("Pushing " + branch + " to " + r + "...")
+		if err := system.RunGit("push", r, branch); err != nil {
+			ui.Error("Push to " + r + " failed (see error.log)")
+			continue
+		}
+		ok++
+	}
+
+	if ok == len(remotes) {
+		ui.Success(fmt.Sprintf("Pushed to %d remote(s)", ok))
+	} else {
+		ui.Warn(fmt.Sprintf("Pushed to %d of %d remote(s)", ok, len(remotes)))
+	}
+	return ok
+}
+
+func listRemotes() {
+	list := Remotes()
+	if len(list) == 0 {
+		ui.Warn("No remotes configured")
+		return
+	}
+
+	def := config.Load().Remote
+	for _, r := range list {
+		if r.Name == def {
+			fmt.Print(ui.Green + "* " + ui.Reset)
+		} else {
+			fmt.Print("  ")
+		}
+		fmt.Println(r)
+	}
+}
+
+func addRemote() {
+	name := ui.Input("Remote name")
+	url := ui.Input("Remote URL")
+
+	if name == "" || url == "" {
+		ui.Error("Remote name and URL are required")
+		return
+	}
+	if RemoteExists(name) {
+		ui.Error("Remote already exists: " + name + " (use Set URL to change it)")
+		return
+	}
+
+	if err := system.RunGit("remote", "add", name, url); err != nil {
+		ui.Error("Failed to add remote")
+		return
+	}
+	ui.Success("Added remote: " + name)
+
+	if ui.Confirm("Make " + name + " the default remote?") {
+		cfg := config.Load()
+		cfg.Remote = name
+		config.Save(cfg)
+		ui.Success("Default remote set to: " + name)
+	}
+}
+
+func renameRemote() {
+	r, ok := pickRemote("Rename")
+	if !ok {
+		return
+	}
+
+	name := ui.Input("New name for " + r.Name)
+	if name == "" {
+		ui.Error("Remote name cannot be empty")
+		return
+	}
+
+	if err := system.RunGit("remote", "rename", r.Name, name); err != nil {
+		ui.Error("Failed to rename remote")
+		return
+	}
+
+	cfg := config.Load()
+	if cfg.Remote == r.Name {
+		cfg.Remote = name
+	}
+	for i, m := range cfg.PushRemotes {
+		if m == r.Name {
+			cfg.PushRemotes[i] = name
+		}
+	}
+	config.Save(cfg)
+
+	ui.Success("Renamed " + r.Name + " → " + name)
+}
+
+func setRemoteURL() {
+	r, ok := pickRemote("Set URL of")
+	if !ok {
+		return
+	}
+
+	fmt.Println(r)
+	url := ui.Input("New URL")
+	if url == "" {
+		ui.Error("URL cannot be empty")
+		return
+	}
+
+	args := []string{"remote", "set-url", r.Name, url}
+	if ui.Confirm("Change only the push URL (fetch stays the same)?") {
+		args = []string{"remote", "set-url", "--push", r.Name, url}
+	}
+
+	if err := system.RunGit(args...); err != nil {
+		ui.Error("Failed to set URL")
+		return
+	}
+	ui.Success("Updated URL of " + r.Name)
+}
+
+func removeRemote() {
+	r, ok := pickRemote("Remove")
+	if !ok {
+		return
+	}
+
+	cfg := config.Load()
+	if r.Name == cfg.Remote {
+		ui.Warn(r.Name + " is the default remote")
+	}
+	if !ui.Confirm("Remove remote " + r.Name + "?") {
+		return
+	}
+
+	if err := system.RunGit("remote", "remove", r.Name); err != nil {
+		ui.Error("Failed to remove remote")
+		return
+	}
+
+	cfg.PushRemotes = without(cfg.PushRemotes, r.Name)
+	config.Save(cfg)
+
+	ui.Success("Removed remote: " + r.Name)
+}
+
+func setDefaultRemote() {
+	r, ok := pickRemote("Default remote")
+	if !ok {
+		return
+	}
+
+	cfg := config.Load()
+	cfg.Remote = r.Name
+	cfg.PushRemotes = without(cfg.PushRemotes, r.Name)
+	config.Save(cfg)
+
+	ui.Success("Default remote set to: " + r.Name)
+}
+
+func chooseMirrors() {
+	cfg := config.Load()
+
+	var names []string
+	for _, r := range Remotes() {
+		if r.Name != cfg.Remote {
+			names = append(names, r.Name)
+		}
+	}
+	if len(names) == 0 {
+		ui.Warn("Add another remote first — the default remote is always pushed")
+		return
+	}
+
+	ui.Info("Every push goes to " + cfg.Remote + " and then to the selected mirrors")
+	picked := ui.MultiSelect("Mirror remotes", names)
+
+	cfg.PushRemotes = nil
+	for _, i := range picked {
+		cfg.PushRemotes = append(cfg.PushRemotes, names[i])
+	}
+	config.Save(cfg)
+
+	if len(cfg.PushRemotes) == 0 {
+		ui.Success("Mirroring disabled")
+		return
+	}
+	ui.Success("Mirrors: " + strings.Join(cfg.PushRemotes, ", "))
+}
+
+func pushToSelected() {
+	branch, ok := EnsureBranch("push")
+	if !ok {
+		return
+	}
+
+	list := Remotes()
+	names := make([]string, len(list))
+	for i, r := range list {
+		names[i] = r.Name
+	}
+
+	picked := ui.MultiSelect("Push "+branch+" to", names)
+	if len(picked) == 0 {
+		return
+	}
+
+	var targets []string
+	for _, i := range picked {
+		targets = append(targets, names[i])
+	}
+	PushToRemotes(branch, targets)
+}
+
+/* ============================================================
+   Helpers
+   ============================================================ */
+
+func pickRemote(label string) (Remote, bool) {
+	list := Remotes()
+	items := make([]string, len(list))
+	for i, r := range list {
+		items[i] = r.Name + "  " + system.Redact(r.FetchURL)
+	}
+
+	i := ui.Select(label, items)
+	if i < 0 {
+		return Remote{}, false
+	}
+	return list[i], true
+}
+
+func without(list []string, name string) []string {
+	var out []string
+	for _, s := range list {
+		if s != name {
+			out = append(out, s)
+		}
+	}
+	return out
+}
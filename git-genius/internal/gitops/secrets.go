@@ -0,0 +1,126 @@
+package gitops
+
+import (
+	"fmt"
+	"os"
+
+	"git-genius/internal/diff"
+	"git-genius/internal/secrets"
+	"git-genius/internal/system"
+	"git-genius/internal/ui"
+)
+
+// allowSecretsEnv skips the secret guard for one run (GENIUS_ALLOW_SECRETS=1)
+const allowSecretsEnv = "GENIUS_ALLOW_SECRETS"
+
+// ScanStaged runs the secret scanner over the staged changes
+func ScanStaged() ([]secrets.Finding, error) {
+	out, err := system.GitOutput("diff", "--cached", "-M", "-U0", "--no-color", "--no-ext-diff")
+	if err != nil {
+		return nil, err
+	}
+	return secrets.Scan(diff.Parse(out), secrets.LoadAllowlist(system.RepoRoot())), nil
+}
+
+// PrintFindings lists scanner findings with file and line
+func PrintFindings(findings []secrets.Finding) {
+	for _, f := range findings {
+		loc := f.File
+		if f.Line > 0 {
+			loc = fmt.Sprintf("%s:%d", f.File, f.Line)
+		}
+		fmt.Printf("  %s%s%s  %s  %s\n", ui.Yellow, loc, ui.Reset, f.Rule, f.Redacted())
+	}
+}
+
+/*
+guardSecrets blocks a commit when the staged changes look like they
+contain credentials. The user can unstage the files, allowlist false
+positives or deliberately commit anyway. It returns true to go ahead,
+plus the environment for the commit: an approved override is passed on
+so a "genius scan" pre-commit hook does not reject it again.
+*/
+func guardSecrets() ([]string, bool) {
+	findings, err := ScanStaged()
+	if err != nil {
+		ui.Warn("Secret scan failed — continuing without it (see error.log)")
+		return nil, true
+	}
+	if len(findings) == 0 {
+		return nil, true
+	}
+	allow := []string{allowSecretsEnv + "=1"}
+
+	ui.Error(fmt.Sprintf("Possible secrets found in %d place(s):", len(findings)))
+	PrintFindings(findings)
+
+	if os.Getenv(allowSecretsEnv) == "1" {
+		ui.Warn(allowSecretsEnv + "=1 is set — committing anyway")
+		return allow, true
+	}
+
+	for {
+		fmt.Println()
+		fmt.Println("1) Unstage the affected files and stop")
+		fmt.Println("2) These are false positives — add them to " + secrets.AllowFile)
+		fmt.Println("3) Commit anyway")
+		fmt.Println("0) Stop (changes stay staged)")
+
+		switch ui.Input("Select option") {
+		case "1":
+			unstage(findings)
+			return nil, false
+		case "2":
+			root := system.RepoRoot()
+			if err := secrets.Allow(root, findings); err != nil {
+				system.LogError("secret allowlist", err)
+				ui.Error("Could not update " + secrets.AllowFile)
+				continue
+			}
+			if err := system.RunGit("add", "--", ":(top)"+secrets.AllowFile); err != nil {
+				ui.Warn("Could not stage " + secrets.AllowFile)
+			}
+			ui.Success("Allowlisted — " + secrets.AllowFile + " is part of this commit")
+			return nil, true
+		case "3":
+			if ui.Confirm("Really commit possible secrets? They are hard to remove once pushed") {
+				return allow, true
+			}
+		case "0", "":
+			ui.Warn("Commit stopped — changes are left staged")
+			return nil, false
+		default:
+			ui.Error("Invalid option, please try again")
+		}
+	}
+}
+
+func unstage(findings []secrets.Finding) {
+	seen := map[string]bool{}
+	var paths []string
+	for _, f := range findings {
+		if !seen[f.File] {
+			seen[f.File] = true
+			paths = append(paths, ":(top)"+f.File)
+		}
+	}
+
+	if err := unstagePaths(paths); err != nil {
+		ui.Error("Failed to unstage files")
+		return
+	}
+	ui.Success(fmt.Sprintf("Unstaged %d file(s) — consider adding them to .gitignore", len(paths)))
+}
+
+/*
+unstagePaths drops staged changes of the pathspecs from the index: files
+known to HEAD go back to their committed version (git rm --cached would
+stage their deletion), new files become untracked again.
+*/
+func unstagePaths(paths []string) error {
+	args := append([]string{"reset", "-q", "--"}, paths...)
+	if Head().Unborn {
+		args = append([]string{"rm", "--cached", "-q", "--"}, paths...)
+	}
+	return system.RunGit(args...)
+}
@@ -0,0 +1,249 @@
+package gitops
+
+import (
+	"fmt"
+	"strings"
+
+	"git-genius/internal/system"
+	"git-genius/internal/ui"
+)
+
+// Stash is one entry of the stash list
+type Stash struct {
+	Ref     string // stash@{n}
+	Branch  string // branch the stash was created on
+	Message string
+	Age     string
+}
+
+// String renders a stash for lists and pickers
+func (s Stash) String() string {
+	return s.Ref + "  " + ui.Cyan + s.Branch + ui.Reset + "  " + s.Message + "  " + ui.Yellow + "(" + s.Age + ")" + ui.Reset
+}
+
+/* ============================================================
+   Queries
+   ============================================================ */
+
+// Stashes lists stash entries, newest first
+func Stashes() []Stash {
+	out, ok := system.GitQuery("stash", "list", "--format=%gd%x1f%gs%x1f%cr")
+	if !ok || out == "" {
+		return nil
+	}
+
+	var list []Stash
+	for _, l := range strings.Split(out, "\n") {
+		f := strings.Split(l, "\x1f")
+		if len(f) < 3 {
+			continue
+		}
+		branch, msg := parseStashSubject(f[1])
+		list = append(list, Stash{Ref: f[0], Branch: branch, Message: msg, Age: f[2]})
+	}
+	return list
+}
+
+// parseStashSubject splits "WIP on main: abc msg" / "On main: msg"
+func parseStashSubject(s string) (string, string) {
+	rest := strings.TrimPrefix(strings.TrimPrefix(s, "WIP on "), "On ")
+	branch, msg, ok := strings.Cut(rest, ": ")
+	if !ok {
+		return "", s
+	}
+	return branch, msg
+}
+
+/* ============================================================
+   Operations
+   ============================================================ */
+
+// StashPush stashes local changes, optionally only the given paths
+func StashPush(msg string, untracked bool, paths ...string) error {
+	args := []string{"stash", "push"}
+	if untracked {
+		args = append(args, "--include-untracked")
+	}
+	if msg != "" {
+		args = append(args, "-m", msg)
+	}
+	if len(paths) > 0 {
+		args = append(args, "--")
+		args = append(args, paths...)
+	}
+	return system.RunGit(args...)
+}
+
+/* ============================================================
+   Stash Manager
+   ============================================================ */
+
+// StashManager is the interactive menu for the stash
+func StashManager() {
+	if !system.EnsureGitRepo() {
+		return
+	}
+
+	for {
+		fmt.Println()
+		ui.Header("Stash Manager")
+		fmt.Printf("%d stash(es)\n\n", len(Stashes()))
+		fmt.Println("1) List stashes")
+		fmt.Println("2) Show stash diff")
+		fmt.Println("3) Create stash")
+		fmt.Println("4) Apply stash")
+		fmt.Println("5) Pop stash")
+		fmt.Println("6) Drop stash")
+		fmt.Println("7) Create branch from stash")
+		fmt.Println("0) Back")
+
+		switch ui.Input("Select option") {
+		case "1":
+			listStashes()
+		case "2":
+			showStash()
+		case "3":
+			createStash()
+		case "4":
+			stashAction("apply", "Applied")
+		case "5":
+			stashAction("pop", "Popped")
+		case "6":
+			dropStash()
+		case "7":
+			stashToBranch()
+		case "0":
+			return
+		default:
+			ui.Error("Invalid option, please try again")
+		}
+	}
+}
+
+func listStashes() {
+	list := Stashes()
+	if len(list) == 0 {
+		ui.Info("No stashes")
+		return
+	}
+	for _, s := range list {
+		fmt.Println(s)
+	}
+}
+
+func showStash() {
+	s, ok := pickStash("Show")
+	if !ok {
+		return
+	}
+
+	// git < 2.32 has no --include-untracked for show
+	if !ViewPatch("stash", "show", "-p", "--include-untracked", s.Ref) &&
+		!ViewPatch("stash", "show", "-p", s.Ref) {
+		ui.Error("Failed to show stash")
+	}
+}
+
+func createStash() {
+	if !IsDirty() {
+		ui.Info("No local changes to stash")
+		return
+	}
+
+	msg := ui.Input("Stash message (optional)")
+	untracked := ui.Confirm("Include untracked files?")
+	paths := strings.Fields(ui.Input("Paths to stash (space separated, empty = everything)"))
+
+	if err := StashPush(msg, untracked, paths...); err != nil {
+		ui.Error("Failed to create stash")
+		return
+	}
+	ui.Success("Changes stashed")
+}
+
+func stashAction(action, done string) {
+	s, ok := pickStash(strings.ToUpper(action[:1]) + action[1:])
+	if !ok {
+		return
+	}
+
+	if action == "pop" {
+		recordStashUndo("stash pop "+s.Ref, s.Ref)
+	} else {
+		recordUndo("stash apply " + s.Ref)
+	}
+	if err := system.RunGit("stash", action, s.Ref); err != nil {
+		if action == "pop" {
+			ui.Error("Pop failed — the stash was kept (conflicts may need resolving)")
+		} else {
+			ui.Error("Apply failed (conflicts may need resolving)")
+		}
+		return
+	}
+	ui.Success(done + " " + s.Ref)
+}
+
+func dropStash() {
+	s, ok := pickStash("Drop")
+	if !ok {
+		return
+	}
+
+	if !ui.Confirm("Drop " + s.Ref + " (" + s.Message + ")? It cannot be recovered easily") {
+		return
+	}
+
+	recordStashUndo("stash drop "+s.Ref, s.Ref)
+	if err := system.RunGit("stash", "drop", s.Ref); err != nil {
+		ui.Error("Failed to drop stash")
+		return
+	}
+	ui.Success("Dropped " + s.Ref)
+}
+
+func stashToBranch() {
+	s, ok := pickStash("Branch from")
+	if !ok {
+		return
+	}
+
+	name := ui.Input("New branch name")
+	if name == "" {
+		ui.Error("Branch name cannot be empty")
+		return
+	}
+	if BranchExists(name) {
+		ui.Error("Branch already exists: " + name)
+		return
+	}
+
+	recordUndo("branch from " + s.Ref)
+	if err := system.RunGit("stash", "branch", name, s.Ref); err != nil {
+		ui.Error("Failed to create branch from stash")
+		return
+	}
+	ui.Success("Created " + name + " from " + s.Ref + " and applied it")
+}
+
+/* ============================================================
+   Helpers
+   ============================================================ */
+
+func pickStash(label string) (Stash, bool) {
+	list := Stashes()
+	if len(list) == 0 {
+		ui.Info("No stashes")
+		return Stash{}, false
+	}
+
+	items := make([]string, len(list))
+	for i, s := range list {
+		items[i] = s.String()
+	}
+
+	i := ui.Select(label, items)
+	if i < 0 {
+		return Stash{}, false
+	}
+	return list[i], true
+}
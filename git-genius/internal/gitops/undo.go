@@ -0,0 +1,616 @@
+package gitops
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"git-genius/internal/config"
+	"git-genius/internal/conflict"
+	"git-genius/internal/system"
+	"git-genius/internal/ui"
+)
+
+const (
+	undoFile     = config.Dir + "/undo.json"
+	maxSnapshots = 50
+)
+
+// Snapshot is the ref state recorded before a mutating operation
+type Snapshot struct {
+	Op     string            `json:"op"`
+	Time   time.Time         `json:"time"`
+	Branch string            `json:"branch"` // checked out branch, empty when detached
+	Head   string            `json:"head"`   // commit HEAD pointed at
+	Refs   map[string]string `json:"refs"`   // local branch → commit
+
+	// State outside refs/heads that the operation changes
+	Stash  *StashRecord      `json:"stash,omitempty"`  // stash entry it removes
+	Tags   map[string]string `json:"tags,omitempty"`   // tag → previous object, "" when new
+	Config map[string]string `json:"config,omitempty"` // config key → previous value, "" when unset
+}
+
+// StashRecord is enough to put a dropped stash back with "git stash store"
+type StashRecord struct {
+	Hash    string `json:"hash"`
+	Subject string `json:"subject"` // e.g. "On main: message"
+}
+
+/* ============================================================
+   Journal
+   ============================================================ */
+
+// The conflict assistant cannot import gitops; it reports through a hook
+func init() {
+	conflict.BeforeChange = recordUndo
+}
+
+// recordUndo saves the current HEAD and branch tips before op runs
+func recordUndo(op string) {
+	saveSnapshot(snapshot(op))
+}
+
+// recordStashUndo also keeps the stash entry ref, which op drops
+func recordStashUndo(op, ref string) {
+	s := snapshot(op)
+	out, _ := system.GitQuery("log", "-g", "-1", "--format=%H%x1f%gs", ref)
+	if hash, subject, ok := strings.Cut(out, "\x1f"); ok {
+		s.Stash = &StashRecord{Hash: hash, Subject: subject}
+	}
+	saveSnapshot(s)
+}
+
+// RecordTagUndo journals the current state and tag before op creates or
+// moves it, for tags made outside gitops (releases)
+func RecordTagUndo(op, tag string) {
+	recordTagUndo(op, tag)
+}
+
+// recordTagUndo also keeps the current object of tag (op creates or moves it)
+func recordTagUndo(op, tag string) {
+	s := snapshot(op)
+	s.Tags = map[string]string{tag: tagObject(tag)}
+	saveSnapshot(s)
+}
+
+// recordConfigUndo also keeps the current values of the config keys op sets
+func recordConfigUndo(op string, keys ...string) {
+	s := snapshot(op)
+	s.Config = map[string]string{}
+	for _, k := range keys {
+		s.Config[k], _ = system.GitQuery("config", "--get", k)
+	}
+	saveSnapshot(s)
+}
+
+func snapshot(op string) Snapshot {
+	h := Head()
+	if h.Unborn {
+		return Snapshot{}
+	}
+
+	s := Snapshot{
+		Op:     op,
+		Time:   time.Now(),
+		Branch: h.Branch,
+		Refs:   map[string]string{},
+	}
+	s.Head, _ = system.GitQuery("rev-parse", "HEAD")
+
+	out, _ := system.GitQuery("for-each-ref", "--format=%(refname:short) %(objectname)", "refs/heads")
+	for _, l := range strings.Split(out, "\n") {
+		if name, sha, ok := strings.Cut(l, " "); ok {
+			s.Refs[name] = sha
+		}
+	}
+	return s
+}
+
+func saveSnapshot(s Snapshot) {
+	if s.Head == "" {
+		return
+	}
+
+	list := loadSnapshots()
+	list = append(list, s)
+	if len(list) > maxSnapshots {
+		list = list[len(list)-maxSnapshots:]
+	}
+	saveSnapshots(list)
+}
+
+func loadSnapshots() []Snapshot {
+	data, err := os.ReadFile(undoFile)
+	if err != nil {
+		return nil
+	}
+	var list []Snapshot
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil
+	}
+	return list
+}
+
+func saveSnapshots(list []Snapshot) {
+	os.MkdirAll(config.Dir, 0700)
+
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return
+	}
+	if err := os.WriteFile(undoFile, data, 0600); err != nil {
+		system.LogError("saving undo journal", err)
+	}
+}
+
+/* ============================================================
+   Undo Menu
+   ============================================================ */
+
+// UndoMenu offers undo of genius operations and reflog based recovery
+func UndoMenu() {
+	if !system.EnsureGitRepo() {
+		return
+	}
+
+	for {
+		fmt.Println()
+		ui.Header("Undo / Safety Net")
+		if list := loadSnapshots(); len(list) > 0 {
+			last := list[len(list)-1]
+			fmt.Printf("Last operation: %s (%s)\n\n", last.Op, last.Time.Format("2006-01-02 15:04"))
+		}
+		fmt.Println("1) Undo last genius operation")
+		fmt.Println("2) Browse reflog")
+		fmt.Println("3) Undo last commit")
+		fmt.Println("4) Recover deleted branch")
+		fmt.Println("0) Back")
+
+		switch ui.Input("Select option") {
+		case "1":
+			UndoLast()
+		case "2":
+			ReflogBrowser()
+		case "3":
+			undoLastCommit()
+		case "4":
+			recoverBranch()
+		case "0":
+			return
+		default:
+			ui.Error("Invalid option, please try again")
+		}
+	}
+}
+
+// UndoLast restores branches to the state before the last recorded operation
+func UndoLast() {
+	list := loadSnapshots()
+	if len(list) == 0 {
+		ui.Info("Nothing to undo")
+		return
+	}
+	s := list[len(list)-1]
+
+	current := map[string]string{}
+	out, _ := system.GitQuery("for-each-ref", "--format=%(refname:short) %(objectname)", "refs/heads")
+	for _, l := range strings.Split(out, "\n") {
+		if name, sha, ok := strings.Cut(l, " "); ok {
+			current[name] = sha
+		}
+	}
+
+	var changed, deleted, created []string
+	for name, sha := range s.Refs {
+		now, ok := current[name]
+		switch {
+		case !ok:
+			deleted = append(deleted, name)
+		case now != sha:
+			changed = append(changed, name)
+		}
+	}
+	for name := range current {
+		if _, ok := s.Refs[name]; !ok {
+			created = append(created, name)
+		}
+	}
+	sort.Strings(changed)
+	sort.Strings(deleted)
+	sort.Strings(created)
+	extras := extraChanges(s)
+
+	h := Head()
+	ui.Info(fmt.Sprintf("Undo \"%s\" from %s", s.Op, s.Time.Format("2006-01-02 15:04")))
+	for _, n := range changed {
+		fmt.Printf("  reset    %s %s → %s\n", n, short(current[n]), short(s.Refs[n]))
+	}
+	for _, n := range deleted {
+		fmt.Printf("  restore  %s at %s\n", n, short(s.Refs[n]))
+	}
+	for _, n := range created {
+		fmt.Printf("  delete   %s (created by the operation)\n", n)
+	}
+	for _, e := range extras {
+		fmt.Println("  " + e.label)
+	}
+	if s.Branch != h.Branch {
+		fmt.Printf("  checkout %s\n", orDetached(s.Branch, s.Head))
+	}
+
+	// Operations that changed nothing (e.g. "nothing to commit") are skipped
+	if len(changed)+len(deleted)+len(created)+len(extras) == 0 && s.Branch == h.Branch {
+		ui.Info("\"" + s.Op + "\" changed nothing — looking further back")
+		saveSnapshots(list[:len(list)-1])
+		UndoLast()
+		return
+	}
+
+	if !ui.Confirm("Apply these changes? Local uncommitted work is kept") {
+		return
+	}
+
+	// Move other branches first; the checked out one needs reset --keep
+	for _, n := range changed {
+		if n == h.Branch {
+			continue
+		}
+		if err := system.RunGit("branch", "-f", n, s.Refs[n]); err != nil {
+			ui.Error("Failed to reset " + n)
+		}
+	}
+	for _, n := range deleted {
+		if err := system.RunGit("branch", n, s.Refs[n]); err != nil {
+			ui.Error("Failed to restore " + n)
+		}
+	}
+
+	for _, n := range changed {
+		if n == h.Branch {
+			if err := system.RunGit("reset", "--keep", s.Refs[n]); err != nil {
+				ui.Error("Failed to reset " + n + " — commit or stash local changes and retry")
+				return
+			}
+		}
+	}
+
+	if s.Branch != h.Branch {
+		target := s.Branch
+		if target == "" {
+			target = s.Head
+		}
+		if err := system.RunGit("checkout", target); err != nil {
+			ui.Error("Failed to checkout " + target)
+			return
+		}
+	}
+
+	for _, n := range created {
+		if n == Head().Branch {
+			continue
+		}
+		if ui.Confirm("Delete branch " + n + " created by the operation?") {
+			_ = system.RunGit("branch", "-D", n)
+		}
+	}
+
+	for _, e := range extras {
+		if err := e.apply(); err != nil {
+			ui.Error("Failed: " + strings.Join(strings.Fields(e.label), " "))
+		}
+	}
+
+	saveSnapshots(list[:len(list)-1])
+	ui.Success("Undid: " + s.Op)
+
+	offerForcePush(changed)
+}
+
+// extraChange restores state outside refs/heads kept in a snapshot
+type extraChange struct {
+	label string
+	apply func() error
+}
+
+// extraChanges lists the recorded tags, config values and stash entries
+// that differ from the current state
+func extraChanges(s Snapshot) []extraChange {
+	var list []extraChange
+
+	for _, tag := range sortedKeys(s.Tags) {
+		tag, prev := tag, s.Tags[tag]
+		switch {
+		case tagObject(tag) == prev:
+		case prev == "":
+			list = append(list, extraChange{"delete   tag " + tag + " (created by the operation)", func() error {
+				return system.RunGit("tag", "-d", tag)
+			}})
+		default:
+			list = append(list, extraChange{"restore  tag " + tag + " at " + short(prev), func() error {
+				return system.RunGit("update-ref", "refs/tags/"+tag, prev)
+			}})
+		}
+	}
+
+	for _, key := range sortedKeys(s.Config) {
+		key, prev := key, s.Config[key]
+		now, _ := system.GitQuery("config", "--get", key)
+		switch {
+		case now == prev:
+		case prev == "":
+			list = append(list, extraChange{"unset    " + key, func() error {
+				return system.RunGit("config", "--unset", key)
+			}})
+		default:
+			list = append(list, extraChange{"config   " + key + " = " + prev, func() error {
+				return system.RunGit("config", key, prev)
+			}})
+		}
+	}
+
+	if st := s.Stash; st != nil && !stashListed(st.Hash) {
+		list = append(list, extraChange{"restore  stash \"" + st.Subject + "\"", func() error {
+			return system.RunGit("stash", "store", "-m", st.Subject, st.Hash)
+		}})
+	}
+	return list
+}
+
+// tagObject returns what refs/tags/name points at ("" when missing)
+func tagObject(name string) string {
+	sha, _ := system.GitQuery("rev-parse", "--verify", "--quiet", "refs/tags/"+name)
+	return sha
+}
+
+func stashListed(hash string) bool {
+	out, _ := system.GitQuery("stash", "list", "--format=%H")
+	for _, h := range strings.Split(out, "\n") {
+		if h == hash {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// offerForcePush handles branches whose undone commits were already pushed
+func offerForcePush(branches []string) {
+	for _, b := range branches {
+		up, ok := system.GitQuery("rev-parse", "--abbrev-ref", b+"@{upstream}")
+		if !ok {
+			continue
+		}
+		if IsMerged(up, b) {
+			continue
+		}
+
+		ui.Warn(b + " no longer matches " + up + " (the undone commits were pushed)")
+		if !ui.Confirm("Force push " + b + " (with lease) to rewrite " + up + "?") {
+			continue
+		}
+
+		remote, name, _ := strings.Cut(up, "/")
+		if err := system.RunGit("push", "--force-with-lease", remote, b+":"+name); err != nil {
+			ui.Error("Force push failed")
+			continue
+		}
+		ui.Success("Rewrote " + up)
+	}
+}
+
+/* ============================================================
+   Reflog
+   ============================================================ */
+
+type reflogEntry struct {
+	Hash     string
+	Selector string
+	Action   string
+	Age      string
+}
+
+func reflog(n int) []reflogEntry {
+	out, ok := system.GitQuery("reflog", "show", fmt.Sprintf("-n%d", n),
+		"--format=%H%x1f%gd%x1f%gs%x1f%cr", "HEAD")
+	if !ok || out == "" {
+		return nil
+	}
+
+	var list []reflogEntry
+	for _, l := range strings.Split(out, "\n") {
+		f := strings.Split(l, "\x1f")
+		if len(f) < 4 {
+			continue
+		}
+		list = append(list, reflogEntry{f[0], f[1], f[2], f[3]})
+	}
+	return list
+}
+
+// ReflogBrowser lets the user restore the current branch to any earlier state
+func ReflogBrowser() {
+	entries := reflog(50)
+	if len(entries) == 0 {
+		ui.Info("Reflog is empty")
+		return
+	}
+
+	items := make([]string, len(entries))
+	for i, e := range entries {
+		items[i] = fmt.Sprintf("%s%s%s %-10s %s %s(%s)%s",
+			ui.Yellow, short(e.Hash), ui.Reset, e.Selector, e.Action, ui.Cyan, e.Age, ui.Reset)
+	}
+
+	ui.Header("Reflog (HEAD)")
+	i := ui.Select("Entry", items)
+	if i < 0 {
+		return
+	}
+	e := entries[i]
+
+	fmt.Println()
+	fmt.Println("1) Restore current branch here (keep local changes)")
+	fmt.Println("2) Restore current branch here (discard local changes)")
+	fmt.Println("3) Create branch here")
+	fmt.Println("4) Show commit")
+	fmt.Println("0) Back")
+
+	switch ui.Input("Select option") {
+	case "1":
+		resetCurrent(e.Hash, "--keep")
+	case "2":
+		resetCurrent(e.Hash, "--hard")
+	case "3":
+		branchAt(e.Hash)
+	case "4":
+		CommitDetail(e.Hash)
+	}
+}
+
+func resetCurrent(hash, mode string) {
+	h := Head()
+	if h.Detached {
+		ui.Error("HEAD is detached — switch to a branch first")
+		return
+	}
+
+	if mode == "--hard" && IsDirty() {
+		ui.Warn("Uncommitted changes will be lost permanently")
+	}
+	if !ui.Confirm("Move " + h.Branch + " to " + short(hash) + "?") {
+		return
+	}
+
+	recordUndo("reset " + h.Branch)
+	if err := system.RunGit("reset", mode, hash); err != nil {
+		ui.Error("Reset failed (local changes may conflict)")
+		return
+	}
+	ui.Success(h.Branch + " is now at " + short(hash))
+}
+
+func undoLastCommit() {
+	h := Head()
+	if h.Detached || h.Unborn {
+		ui.Error("Need a branch with at least one commit")
+		return
+	}
+	if _, ok := system.GitQuery("rev-parse", "--verify", "--quiet", "HEAD~1"); !ok {
+		ui.Error("The first commit cannot be undone this way")
+		return
+	}
+
+	_ = system.RunGit("--no-pager", "log", "-1", "--format=%h %s (%an, %ar)")
+	if onRemote("HEAD") {
+		ui.Warn("This commit is already on a remote — undoing it rewrites published history")
+	}
+
+	fmt.Println("1) Soft  — keep changes staged")
+	fmt.Println("2) Mixed — keep changes unstaged")
+	fmt.Println("0) Cancel")
+
+	mode := ""
+	switch ui.Input("Select option") {
+	case "1":
+		mode = "--soft"
+	case "2":
+		mode = "--mixed"
+	default:
+		return
+	}
+
+	recordUndo("undo last commit")
+	if err := system.RunGit("reset", mode, "HEAD~1"); err != nil {
+		ui.Error("Failed to undo last commit")
+		return
+	}
+	ui.Success("Last commit undone — its changes are still in your work tree")
+}
+
+func recoverBranch() {
+	type candidate struct {
+		name, hash, source string
+	}
+
+	found := map[string]candidate{}
+
+	// Newest snapshots win
+	for _, s := range loadSnapshots() {
+		for name, sha := range s.Refs {
+			if !BranchExists(name) {
+				found[name] = candidate{name, sha, "genius journal " + s.Time.Format("2006-01-02")}
+			}
+		}
+	}
+
+	// "checkout: moving from X to Y" — X's tip is the entry just before
+	entries := reflog(500)
+	for i, e := range entries {
+		rest, ok := strings.CutPrefix(e.Action, "checkout: moving from ")
+		if !ok || i+1 >= len(entries) {
+			continue
+		}
+		name, _, _ := strings.Cut(rest, " to ")
+		if _, seen := found[name]; seen || BranchExists(name) || len(name) == 40 {
+			continue
+		}
+		found[name] = candidate{name, entries[i+1].Hash, "reflog " + e.Age}
+	}
+
+	if len(found) == 0 {
+		ui.Info("No deleted branches found")
+		return
+	}
+
+	var list []candidate
+	for _, c := range found {
+		list = append(list, c)
+	}
+	sort.Slice(list, func(a, b int) bool { return list[a].name < list[b].name })
+
+	items := make([]string, len(list))
+	for i, c := range list {
+		items[i] = fmt.Sprintf("%s at %s%s%s (%s)", c.name, ui.Yellow, short(c.hash), ui.Reset, c.source)
+	}
+
+	i := ui.Select("Recover", items)
+	if i < 0 {
+		return
+	}
+	c := list[i]
+
+	recordUndo("recover branch " + c.name)
+	if err := system.RunGit("branch", c.name, c.hash); err != nil {
+		ui.Error("Failed to recover " + c.name)
+		return
+	}
+	ui.Success("Recovered " + c.name + " at " + short(c.hash))
+}
+
+/* ============================================================
+   Helpers
+   ============================================================ */
+
+// onRemote reports whether a commit is reachable from any remote branch
+func onRemote(rev string) bool {
+	out, ok := system.GitQuery("branch", "-r", "--contains", rev)
+	return ok && out != ""
+}
+
+func orDetached(branch, head string) string {
+	if branch == "" {
+		return "detached " + short(head)
+	}
+	return branch
+}
@@ -0,0 +1,203 @@
+package hooks
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"git-genius/internal/system"
+)
+
+// ConfigFile is the checked-in hook configuration, relative to the repo root
+const ConfigFile = ".genius-hooks.json"
+
+// Names are the hooks genius manages, in the order git runs them
+var Names = []string{"pre-commit", "commit-msg", "pre-push"}
+
+// marker identifies scripts written by genius
+const marker = "# managed by git-genius"
+
+// backupSuffix keeps a hook that existed before genius took over
+const backupSuffix = ".genius-backup"
+
+/*
+Command is one check run by a hook. Files limits it to changes matching
+any of the globs (a glob without "/" matches the base name); the matched
+files are passed in $GENIUS_FILES, one per line.
+*/
+type Command struct {
+	Name  string   `json:"name"`
+	Run   string   `json:"run"`
+	Files []string `json:"files,omitempty"`
+}
+
+// Config maps hook names to their commands
+type Config map[string][]Command
+
+/* ============================================================
+   Configuration
+   ============================================================ */
+
+// ConfigPath returns the absolute path of ConfigFile
+func ConfigPath() string {
+	return filepath.Join(system.RepoRoot(), ConfigFile)
+}
+
+// Load reads ConfigFile; a missing file is an empty config
+func Load() (Config, error) {
+	data, err := os.ReadFile(ConfigPath())
+	if os.IsNotExist(err) {
+		return Config{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var c Config
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, errors.New(ConfigFile + ": " + err.Error())
+	}
+	for name := range c {
+		if !valid(name) {
+			return nil, errors.New(ConfigFile + ": unsupported hook " + name)
+		}
+	}
+	return c, nil
+}
+
+// Save writes the config to ConfigFile
+func Save(c Config) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(ConfigPath(), append(data, '\n'), 0644)
+}
+
+/*
+Sample returns a starting config: secret scanning before each commit,
+message rules for commit-msg, and the project's tests before pushing.
+*/
+func Sample() Config {
+	c := Config{
+		"pre-commit": {{Name: "secret scan", Run: "genius scan"}},
+		"commit-msg": {{Name: "message rules", Run: `genius check-message --file "$1"`}},
+		"pre-push":   {},
+	}
+
+	root := system.RepoRoot()
+	if exists(filepath.Join(root, "go.mod")) {
+		c["pre-commit"] = append(c["pre-commit"], Command{
+			Name: "gofmt", Run: `test -z "$(gofmt -l $GENIUS_FILES)"`, Files: []string{"*.go"},
+		})
+		c["pre-push"] = append(c["pre-push"], Command{Name: "go vet", Run: "go vet ./..."},
+			Command{Name: "go test", Run: "go test ./..."})
+	}
+	if exists(filepath.Join(root, "package.json")) {
+		c["pre-push"] = append(c["pre-push"], Command{Name: "npm test", Run: "npm test --silent"})
+	}
+	return c
+}
+
+/* ============================================================
+   Installation
+   ============================================================ */
+
+// Status of a hook script in the hooks directory
+const (
+	NotInstalled = "not installed"
+	Installed    = "installed"
+	Foreign      = "other script" // a hook genius did not write
+)
+
+// Status reports whether the hook script is genius-managed
+func Status(name string) string {
+	data, err := os.ReadFile(scriptPath(name))
+	if err != nil {
+		return NotInstalled
+	}
+	if strings.Contains(string(data), marker) {
+		return Installed
+	}
+	return Foreign
+}
+
+/*
+Install writes the hook script that calls "genius hook run <name>".
+An existing foreign hook is kept as <name>.genius-backup.
+*/
+func Install(name string) error {
+	if !valid(name) {
+		return errors.New("unsupported hook " + name)
+	}
+
+	p := scriptPath(name)
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return err
+	}
+	if Status(name) == Foreign {
+		if err := os.Rename(p, p+backupSuffix); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(p, []byte(script(name)), 0755)
+}
+
+// Uninstall removes a genius hook and restores a backed-up one
+func Uninstall(name string) error {
+	if Status(name) != Installed {
+		return nil
+	}
+
+	p := scriptPath(name)
+	if err := os.Remove(p); err != nil {
+		return err
+	}
+	if exists(p + backupSuffix) {
+		return os.Rename(p+backupSuffix, p)
+	}
+	return nil
+}
+
+// script falls back to genius on PATH when the binary has moved
+func script(name string) string {
+	self, err := os.Executable()
+	if err != nil {
+		self = "genius"
+	}
+
+	return "#!/bin/sh\n" +
+		marker + " — edit " + ConfigFile + " instead\n" +
+		"GENIUS=" + shellQuote(self) + "\n" +
+		"command -v \"$GENIUS\" >/dev/null 2>&1 || GENIUS=genius\n" +
+		"exec \"$GENIUS\" hook run " + name + " \"$@\"\n"
+}
+
+// scriptPath honours core.hooksPath and worktrees
+func scriptPath(name string) string {
+	return filepath.Join(system.GitPath("hooks"), name)
+}
+
+/* ============================================================
+   Helpers
+   ============================================================ */
+
+func valid(name string) bool {
+	for _, n := range Names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+func exists(p string) bool {
+	_, err := os.Stat(p)
+	return err == nil
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
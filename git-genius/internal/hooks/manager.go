@@ -0,0 +1,135 @@
+package hooks
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"git-genius/internal/system"
+	"git-genius/internal/ui"
+)
+
+// Manager is the interactive hooks menu
+func Manager() {
+	if !system.EnsureGitRepo() {
+		return
+	}
+
+	for {
+		fmt.Println()
+		ui.Header("Git Hooks")
+		List()
+
+		fmt.Println()
+		fmt.Println("1) Enable (install) hooks")
+		fmt.Println("2) Disable hooks")
+		fmt.Println("3) Run a hook now")
+		fmt.Println("4) Create " + ConfigFile)
+		fmt.Println("5) Edit " + ConfigFile)
+		fmt.Println("0) Back")
+
+		switch ui.Input("Select option") {
+		case "1":
+			for _, name := range pickHooks("Enable") {
+				if err := Install(name); err != nil {
+					system.LogError("install hook "+name, err)
+					ui.Error("Failed to install " + name + ": " + err.Error())
+					continue
+				}
+				ui.Success("Enabled " + name)
+			}
+		case "2":
+			for _, name := range pickHooks("Disable") {
+				if err := Uninstall(name); err != nil {
+					system.LogError("uninstall hook "+name, err)
+					ui.Error("Failed to remove " + name + ": " + err.Error())
+					continue
+				}
+				ui.Success("Disabled " + name)
+			}
+		case "3":
+			runNow()
+		case "4":
+			createConfig()
+		case "5":
+			if err := system.OpenEditor(ConfigPath()); err != nil {
+				ui.Error("Could not open editor: " + err.Error())
+			}
+		case "0", "":
+			return
+		default:
+			ui.Error("Invalid option, please try again")
+		}
+	}
+}
+
+// List prints every managed hook with its state and commands
+func List() {
+	cfg, err := Load()
+	if err != nil {
+		ui.Error(err.Error())
+		cfg = Config{}
+	}
+
+	for _, name := range Names {
+		state := Status(name)
+		color := ui.Yellow
+		switch state {
+		case Installed:
+			color = ui.Green
+		case Foreign:
+			color = ui.Red
+		}
+		fmt.Printf("%-11s %s%s%s\n", name, color, state, ui.Reset)
+
+		if len(cfg[name]) == 0 {
+			fmt.Println("            (no commands)")
+		}
+		for _, c := range cfg[name] {
+			line := "            • " + c.Name + ": " + c.Run
+			if len(c.Files) > 0 {
+				line += "  [" + strings.Join(c.Files, " ") + "]"
+			}
+			fmt.Println(line)
+		}
+	}
+}
+
+func pickHooks(action string) []string {
+	var names []string
+	for _, i := range ui.MultiSelect(action, Names) {
+		names = append(names, Names[i])
+	}
+	return names
+}
+
+func runNow() {
+	i := ui.Select("Hook", Names)
+	if i < 0 {
+		return
+	}
+	if Names[i] == "commit-msg" {
+		ui.Warn("commit-msg needs a message file — it runs on the next commit")
+		return
+	}
+
+	// Simulate pushing HEAD as a new branch: files of all unpushed commits
+	var refs io.Reader
+	if Names[i] == "pre-push" {
+		head, _ := system.GitQuery("rev-parse", "HEAD")
+		refs = strings.NewReader("HEAD " + head + " HEAD " + zeroHash + "\n")
+	}
+	Run(Names[i], nil, refs)
+}
+
+func createConfig() {
+	if exists(ConfigPath()) && !ui.Confirm(ConfigFile+" exists. Overwrite it?") {
+		return
+	}
+	if err := Save(Sample()); err != nil {
+		system.LogError("write "+ConfigFile, err)
+		ui.Error("Could not write " + ConfigFile + ": " + err.Error())
+		return
+	}
+	ui.Success("Created " + ConfigFile + " — commit it to share the hooks with your team")
+}
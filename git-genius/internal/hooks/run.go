@@ -0,0 +1,201 @@
+package hooks
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"git-genius/internal/system"
+	"git-genius/internal/ui"
+)
+
+// zeroHash is what pre-push reports for refs that don't exist
+// (64 zeros in SHA-256 repositories)
+const zeroHash = "0000000000000000000000000000000000000000"
+
+func isZero(hash string) bool {
+	return len(hash) >= len(zeroHash) && strings.Trim(hash, "0") == ""
+}
+
+// Result is the outcome of one hook command
+type Result struct {
+	Command  Command
+	Skipped  bool // no matching files
+	Err      error
+	Output   string
+	Duration time.Duration
+}
+
+/*
+Run executes the commands configured for a hook in parallel and prints
+a summary. args are the hook's arguments from git (e.g. the message file
+for commit-msg); they are available as $1... in each command. refs is
+the ref list git feeds pre-push on stdin (nil for other hooks).
+It returns false when any command failed.
+*/
+func Run(name string, args []string, refs io.Reader) bool {
+	cfg, err := Load()
+	if err != nil {
+		ui.Error(err.Error())
+		return false
+	}
+
+	cmds := cfg[name]
+	if len(cmds) == 0 {
+		return true
+	}
+
+	files := changedFiles(name, refs)
+
+	results := make([]Result, len(cmds))
+	var wg sync.WaitGroup
+	for i, c := range cmds {
+		wg.Add(1)
+		go func(i int, c Command) {
+			defer wg.Done()
+			results[i] = runCommand(name, c, args, files)
+		}(i, c)
+	}
+	wg.Wait()
+
+	return summarize(name, results)
+}
+
+func runCommand(hook string, c Command, args, files []string) Result {
+	r := Result{Command: c}
+
+	matched := files
+	if len(c.Files) > 0 {
+		matched = filterFiles(files, c.Files)
+		if len(matched) == 0 {
+			r.Skipped = true
+			return r
+		}
+	}
+
+	cmd := exec.Command("sh", append([]string{"-c", c.Run, "sh"}, args...)...)
+	cmd.Dir = system.RepoRoot()
+	cmd.Env = append(os.Environ(),
+		"GENIUS_HOOK="+hook,
+		"GENIUS_FILES="+strings.Join(matched, "\n"),
+	)
+
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+
+	start := time.Now()
+	r.Err = cmd.Run()
+	r.Duration = time.Since(start)
+	r.Output = strings.TrimSpace(out.String())
+	return r
+}
+
+func summarize(hook string, results []Result) bool {
+	failed := 0
+	for _, r := range results {
+		label := r.Command.Name
+		if label == "" {
+			label = r.Command.Run
+		}
+
+		switch {
+		case r.Skipped:
+			fmt.Printf("  %s-%s %s (no matching files)\n", ui.Cyan, ui.Reset, label)
+		case r.Err != nil:
+			failed++
+			fmt.Printf("  %s✘%s %s (%s)\n", ui.Red, ui.Reset, label, r.Duration.Round(time.Millisecond))
+			if r.Output != "" {
+				for _, l := range strings.Split(r.Output, "\n") {
+					fmt.Println("      " + l)
+				}
+			}
+		default:
+			fmt.Printf("  %s✔%s %s (%s)\n", ui.Green, ui.Reset, label, r.Duration.Round(time.Millisecond))
+		}
+	}
+
+	if failed > 0 {
+		ui.Error(fmt.Sprintf("%s: %d of %d check(s) failed", hook, failed, len(results)))
+		return false
+	}
+	ui.Success(hook + ": all checks passed")
+	return true
+}
+
+/* ============================================================
+   Files
+   ============================================================ */
+
+// changedFiles lists the files a hook is about: staged files for commits,
+// files in the pushed commits for pre-push
+func changedFiles(hook string, refs io.Reader) []string {
+	if hook != "pre-push" {
+		out, _ := system.GitQuery("diff", "--cached", "--name-only", "--diff-filter=ACMR")
+		return lines(out)
+	}
+
+	if refs == nil {
+		return nil
+	}
+
+	// Lines are "<local ref> <local sha> <remote ref> <remote sha>"
+	seen := map[string]bool{}
+	var files []string
+
+	sc := bufio.NewScanner(refs)
+	for sc.Scan() {
+		f := strings.Fields(sc.Text())
+		if len(f) < 4 || isZero(f[1]) {
+			continue
+		}
+
+		var out string
+		if isZero(f[3]) {
+			out, _ = system.GitQuery("log", "--name-only", "--format=", f[1], "--not", "--remotes")
+		} else {
+			out, _ = system.GitQuery("diff", "--name-only", "--diff-filter=ACMR", f[3], f[1])
+		}
+		for _, p := range lines(out) {
+			if !seen[p] {
+				seen[p] = true
+				files = append(files, p)
+			}
+		}
+	}
+	return files
+}
+
+func filterFiles(files, globs []string) []string {
+	var out []string
+	for _, f := range files {
+		for _, g := range globs {
+			target := f
+			if !strings.Contains(g, "/") {
+				target = path.Base(f)
+			}
+			if ok, _ := path.Match(g, target); ok {
+				out = append(out, f)
+				break
+			}
+		}
+	}
+	return out
+}
+
+func lines(s string) []string {
+	var out []string
+	for _, l := range strings.Split(s, "\n") {
+		if l = strings.TrimSpace(l); l != "" {
+			out = append(out, l)
+		}
+	}
+	return out
+}
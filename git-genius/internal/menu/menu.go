@@ -5,9 +5,13 @@ import (
 	"os"
 	"path/filepath"
 
+	"git-genius/internal/changelog"
 	"git-genius/internal/config"
 	"git-genius/internal/doctor"
+	"git-genius/internal/gitignore"
 	"git-genius/internal/gitops"
+	"git-genius/internal/hooks"
+	"git-genius/internal/release"
 	"git-genius/internal/setup"
 	"git-genius/internal/ui"
 )
@@ -29,7 +33,11 @@ func Start() {
 		// -------- Context Panel --------
 		fmt.Println("Project :", filepath.Base(projectDir))
 		fmt.Println("Path    :", projectDir)
-		fmt.Println("Branch  :", gitops.CurrentBranch())
+		head := gitops.Head()
+		fmt.Println("Branch  :", head)
+		if head.Branch != "" && head.Branch != cfg.Branch {
+			fmt.Println("Default :", cfg.Branch, ui.Yellow+"(not checked out)"+ui.Reset)
+		}
 		fmt.Println("Remote  :", gitops.CurrentRemote())
 
 		if cfg.Owner != "" && cfg.Repo != "" {
@@ -41,28 +49,35 @@ func Start() {
 		fmt.Println("1) Push changes")
 		fmt.Println("2) Pull changes")
 		fmt.Println("3) Fetch all remotes")
-		fmt.Println("4) Switch branch")
-		fmt.Println("5) Switch remote")
+		fmt.Println("4) Branch manager")
+		fmt.Println("5) Remote manager")
 		fmt.Println("6) Git status")
 		fmt.Println("7) Setup / Reconfigure")
 		fmt.Println("8) Doctor (health check)")
-		fmt.Println("9) Exit")
+		fmt.Println("9) Stash manager")
+		fmt.Println("10) History")
+		fmt.Println("11) Undo / reflog")
+		fmt.Println("12) Rewrite commits (amend, rebase, cherry-pick)")
+		fmt.Println("13) Bisect (find a regression)")
+		fmt.Println("14) Release tools (changelog, version bump)")
+		fmt.Println("15) Repository tools (.gitignore, hooks)")
+		fmt.Println("0) Exit")
 
 		switch ui.Input("Select option") {
 		case "1":
-			gitops.Push(ui.Input("Commit message"))
+			gitops.Push("")
 
 		case "2":
-			gitops.Pull()
+			gitops.Pull(ui.Input("Pull strategy: merge / rebase / ff-only [" + cfg.PullStrategy + "]"))
 
 		case "3":
 			gitops.Fetch()
 
 		case "4":
-			gitops.SwitchBranch()
+			gitops.BranchManager()
 
 		case "5":
-			gitops.SwitchRemote()
+			gitops.RemoteManager()
 
 		case "6":
 			gitops.Status()
@@ -74,6 +89,27 @@ func Start() {
 			doctor.Run()
 
 		case "9":
+			gitops.StashManager()
+
+		case "10":
+			historyMenu()
+
+		case "11":
+			gitops.UndoMenu()
+
+		case "12":
+			rewriteMenu()
+
+		case "13":
+			gitops.Bisect()
+
+		case "14":
+			releaseMenu()
+
+		case "15":
+			repoMenu()
+
+		case "0":
 			ui.Info("Goodbye 👋")
 			os.Exit(0)
 
@@ -84,3 +120,108 @@ func Start() {
 		ui.Pause()
 	}
 }
+
+func historyMenu() {
+	for {
+		fmt.Println()
+		ui.Header("History")
+		fmt.Println("1) Commit log browser")
+		fmt.Println("2) Diff viewer")
+		fmt.Println("3) File history")
+		fmt.Println("4) Blame")
+		fmt.Println("0) Back")
+
+		switch ui.Input("Select option") {
+		case "1":
+			gitops.LogBrowser()
+		case "2":
+			gitops.DiffViewer()
+		case "3":
+			gitops.FileHistory()
+		case "4":
+			gitops.BlameView()
+		case "0":
+			return
+		default:
+			ui.Error("Invalid option, please try again")
+		}
+	}
+}
+
+func rewriteMenu() {
+	for {
+		fmt.Println()
+		ui.Header("Rewrite Commits")
+		fmt.Println("1) Amend last commit")
+		fmt.Println("2) Reword an unpushed commit")
+		fmt.Println("3) Interactive rebase (squash, reorder, drop)")
+		fmt.Println("4) Continue / abort a paused rebase")
+		fmt.Println("5) Cherry-pick")
+		fmt.Println("6) Revert")
+		fmt.Println("0) Back")
+
+		switch ui.Input("Select option") {
+		case "1":
+			gitops.Amend()
+		case "2":
+			gitops.Reword()
+		case "3":
+			gitops.InteractiveRebase()
+		case "4":
+			gitops.ContinueRebase()
+		case "5":
+			gitops.CherryPick()
+		case "6":
+			gitops.Revert()
+		case "0":
+			return
+		default:
+			ui.Error("Invalid option, please try again")
+		}
+	}
+}
+
+func releaseMenu() {
+	for {
+		fmt.Println()
+		ui.Header("Release Tools")
+		fmt.Println("1) Generate changelog")
+		fmt.Println("2) Bump version (tag & push)")
+		fmt.Println("3) Version files")
+		fmt.Println("0) Back")
+
+		switch ui.Input("Select option") {
+		case "1":
+			changelog.Run()
+		case "2":
+			release.BumpMenu()
+		case "3":
+			release.VersionFiles()
+		case "0":
+			return
+		default:
+			ui.Error("Invalid option, please try again")
+		}
+	}
+}
+
+func repoMenu() {
+	for {
+		fmt.Println()
+		ui.Header("Repository Tools")
+		fmt.Println("1) Generate .gitignore")
+		fmt.Println("2) Git hooks")
+		fmt.Println("0) Back")
+
+		switch ui.Input("Select option") {
+		case "1":
+			gitignore.Run()
+		case "2":
+			hooks.Manager()
+		case "0":
+			return
+		default:
+			ui.Error("Invalid option, please try again")
+		}
+	}
+}
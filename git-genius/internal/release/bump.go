@@ -0,0 +1,370 @@
+package release
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"git-genius/internal/changelog"
+	"git-genius/internal/commitmsg"
+	"git-genius/internal/config"
+	"git-genius/internal/gitops"
+	"git-genius/internal/system"
+	"git-genius/internal/ui"
+)
+
+// Plan describes a version bump before it is applied
+type Plan struct {
+	From      string  // previous tag ("" when there is none)
+	Current   Version // version of From (0.0.0 without tags)
+	Next      Version
+	Level     string // level actually used (auto resolved)
+	Commits   int    // commits since From
+	Files     []string
+	Changelog bool
+	Push      bool
+}
+
+/*
+NewPlan reads the latest semver tag and computes the next version.
+Level Auto infers major/minor/patch from the commit types since the tag;
+pre is an optional pre-release id (e.g. rc, beta).
+*/
+func NewPlan(level, pre string) (Plan, error) {
+	switch level {
+	case "", Auto:
+		level = Auto
+	case Major, Minor, Patch:
+	default:
+		return Plan{}, fmt.Errorf("unknown level %q (use major, minor, patch or auto)", level)
+	}
+
+	p := Plan{Current: Version{Prefix: "v"}, Files: config.Load().VersionFiles, Push: true}
+	if tag, v, ok := LatestTag(); ok {
+		p.From, p.Current = tag, v
+	}
+
+	msgs, err := commitsSince(p.From)
+	if err != nil {
+		return Plan{}, err
+	}
+	p.Commits = len(msgs)
+
+	p.Level = level
+	if level == Auto {
+		p.Level = InferLevel(msgs)
+	}
+	p.Next = p.Current.Bump(p.Level, pre)
+	return p, nil
+}
+
+// InferLevel maps commit messages to a bump: breaking → major, feat → minor
+func InferLevel(msgs []string) string {
+	level := Patch
+	for _, m := range msgs {
+		c, ok := commitmsg.Parse(m)
+		if !ok {
+			continue
+		}
+		if c.Breaking {
+			return Major
+		}
+		if c.Type == "feat" {
+			level = Minor
+		}
+	}
+	return level
+}
+
+func commitsSince(tag string) ([]string, error) {
+	rng := "HEAD"
+	if tag != "" {
+		rng = tag + "..HEAD"
+	}
+	out, err := system.GitOutput("log", "--no-merges", "--format=%B%x1e", rng)
+	if err != nil {
+		return nil, err
+	}
+
+	var msgs []string
+	for _, m := range strings.Split(out, "\x1e") {
+		if m = strings.TrimSpace(m); m != "" {
+			msgs = append(msgs, m)
+		}
+	}
+	return msgs, nil
+}
+
+// Show prints the plan
+func (p Plan) Show() {
+	from := p.From
+	if from == "" {
+		from = "(no tags yet)"
+	}
+	fmt.Println("Current  :", from)
+	fmt.Println("Commits  :", p.Commits)
+	fmt.Println("Level    :", p.Level)
+	fmt.Println("Next     :", ui.Green+p.Next.String()+ui.Reset)
+	if len(p.Files) > 0 {
+		fmt.Println("Files    :", strings.Join(p.Files, ", "))
+	}
+	fmt.Println("Changelog:", yesNo(p.Changelog))
+	fmt.Println("Push     :", yesNo(p.Push))
+}
+
+/*
+Apply updates the version files and changelog, commits, creates an
+annotated tag and pushes both through the regular push path.
+The working tree must be clean so only release changes are committed.
+*/
+func (p Plan) Apply() bool {
+	if gitops.IsDirty() {
+		ui.Error("Working tree has uncommitted changes — commit or stash them first")
+		return false
+	}
+	if _, exists := system.GitQuery("rev-parse", "--verify", "--quiet", "refs/tags/"+p.Next.String()); exists {
+		ui.Error("Tag " + p.Next.String() + " already exists")
+		return false
+	}
+
+	// Check the message first so a rejected commit cannot leave the
+	// version files half released
+	msg, err := commitMessage(p.Next)
+	if err != nil && (len(p.Files) > 0 || p.Changelog) {
+		ui.Error(err.Error())
+		return false
+	}
+
+	for _, f := range p.Files {
+		switch err := updateFile(f, p.Current.Plain(), p.Next.Plain()); {
+		case err == errNoVersion:
+			ui.Warn(f + ": version " + p.Current.Plain() + " not found, left unchanged")
+		case err != nil:
+			ui.Error("Failed to update " + f + ": " + err.Error())
+			system.LogError("version bump "+f, err)
+			return false
+		default:
+			ui.Success("Updated " + f)
+		}
+	}
+
+	if p.Changelog {
+		_, err := changelog.Generate(changelog.Options{
+			Version: p.Next.String(),
+			From:    p.From,
+			To:      "HEAD",
+			File:    changelog.DefaultFile,
+		})
+		if err != nil {
+			ui.Error("Changelog generation failed: " + err.Error())
+			return false
+		}
+	}
+
+	if gitops.IsDirty() {
+		committed := false
+		if p.Push {
+			committed = gitops.Push(msg)
+		} else {
+			committed = gitops.CommitAll(msg)
+		}
+		if !committed {
+			ui.Error("Release commit or push failed — tag not created")
+			return false
+		}
+	}
+
+	tag := p.Next.String()
+	gitops.RecordTagUndo("release "+tag, tag)
+	if err := system.RunGit("tag", "-a", tag, "-m", "Release "+tag); err != nil {
+		ui.Error("Failed to create tag")
+		return false
+	}
+	ui.Success("Created tag " + tag)
+
+	if !p.Push {
+		return true
+	}
+
+	cfg := config.Load()
+	if err := system.RunGit("push", cfg.Remote, tag); err != nil {
+		ui.Error("Tag push failed (see error.log)")
+		return false
+	}
+	if len(cfg.PushRemotes) > 0 {
+		gitops.PushToRemotes(tag, cfg.PushRemotes)
+	}
+	ui.Success("Released " + tag)
+	return true
+}
+
+// commitMessage picks the first release subject the commit rules accept
+func commitMessage(v Version) (string, error) {
+	cfg := config.Load()
+	candidates := []string{"Release " + v.String(), "chore(release): " + v.String(), "release: " + v.String(), v.String()}
+	if cfg.CommitStyle == commitmsg.StyleConventional {
+		candidates = candidates[1:]
+	}
+
+	for _, msg := range candidates {
+		if commitmsg.Validate(msg, cfg) == nil {
+			return msg, nil
+		}
+	}
+	return "", errors.New("no release commit message fits the configured commit rules — adjust them in Setup / Reconfigure")
+}
+
+/* ============================================================
+   Version files
+   ============================================================ */
+
+var errNoVersion = errors.New("version not found")
+
+/*
+updateFile replaces old with next in a version file. Lines mentioning
+"version" are preferred so dependency pins with the same number survive;
+without such a line every occurrence is replaced.
+*/
+func updateFile(path, old, next string) error {
+	full := path
+	if !filepath.IsAbs(full) {
+		full = filepath.Join(system.RepoRoot(), path)
+	}
+
+	data, err := os.ReadFile(full)
+	if err != nil {
+		return err
+	}
+
+	lines := strings.Split(string(data), "\n")
+	changed := false
+	for i, l := range lines {
+		if strings.Contains(strings.ToLower(l), "version") && strings.Contains(l, old) {
+			lines[i] = strings.ReplaceAll(l, old, next)
+			changed = true
+		}
+	}
+
+	out := strings.Join(lines, "\n")
+	if !changed {
+		if !strings.Contains(out, old) {
+			return errNoVersion
+		}
+		out = strings.ReplaceAll(out, old, next)
+	}
+
+	info, err := os.Stat(full)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(full, []byte(out), info.Mode())
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+/* ============================================================
+   Interactive
+   ============================================================ */
+
+// BumpMenu walks through a version bump from the menu
+func BumpMenu() {
+	ui.Header("Version Bump")
+
+	if !system.EnsureGitRepo() {
+		return
+	}
+
+	auto, err := NewPlan(Auto, "")
+	if err != nil {
+		ui.Error("Could not read commit history")
+		return
+	}
+	if auto.Commits == 0 && auto.From != "" {
+		ui.Warn("No commits since " + auto.From)
+		if !ui.Confirm("Bump anyway?") {
+			return
+		}
+	}
+
+	levels := []string{
+		"auto (" + auto.Level + " → " + auto.Next.String() + ")",
+		Major + " → " + auto.Current.Bump(Major, "").String(),
+		Minor + " → " + auto.Current.Bump(Minor, "").String(),
+		Patch + " → " + auto.Current.Bump(Patch, "").String(),
+	}
+	i := ui.Select("Bump level", levels)
+	if i < 0 {
+		return
+	}
+	level := []string{Auto, Major, Minor, Patch}[i]
+
+	pre := ui.Input("Pre-release id (optional, e.g. rc, beta)")
+
+	p, err := NewPlan(level, pre)
+	if err != nil {
+		ui.Error(err.Error())
+		return
+	}
+	p.Changelog = ui.Confirm("Update " + changelog.DefaultFile + "?")
+	p.Push = ui.Confirm("Push the release commit and tag?")
+
+	fmt.Println()
+	p.Show()
+	if !ui.Confirm("Release " + p.Next.String() + "?") {
+		return
+	}
+	p.Apply()
+}
+
+// VersionFiles edits the files whose version strings are bumped
+func VersionFiles() {
+	for {
+		cfg := config.Load()
+
+		fmt.Println()
+		ui.Header("Version Files")
+		if len(cfg.VersionFiles) == 0 {
+			ui.Info("No version files configured")
+		}
+		for i, f := range cfg.VersionFiles {
+			fmt.Printf("%d) %s\n", i+1, f)
+		}
+		fmt.Println()
+		fmt.Println("a) Add file")
+		fmt.Println("r) Remove file")
+		fmt.Println("0) Back")
+
+		switch ui.Input("Select option") {
+		case "a":
+			f := ui.Input("Path relative to the repository root (e.g. package.json)")
+			if f == "" {
+				continue
+			}
+			if _, err := os.Stat(filepath.Join(system.RepoRoot(), f)); err != nil {
+				ui.Warn(f + " does not exist yet")
+			}
+			cfg.VersionFiles = append(cfg.VersionFiles, f)
+			config.Save(cfg)
+			ui.Success("Added " + f)
+		case "r":
+			i := ui.Select("Remove", cfg.VersionFiles)
+			if i < 0 {
+				continue
+			}
+			ui.Success("Removed " + cfg.VersionFiles[i])
+			cfg.VersionFiles = append(cfg.VersionFiles[:i], cfg.VersionFiles[i+1:]...)
+			config.Save(cfg)
+		case "0", "":
+			return
+		default:
+			ui.Error("Invalid option, please try again")
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package release
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"git-genius/internal/system"
+)
+
+// Bump levels
+const (
+	Major = "major"
+	Minor = "minor"
+	Patch = "patch"
+	Auto  = "auto" // inferred from commit types
+)
+
+var semverPattern = regexp.MustCompile(`^(v?)(\d+)\.(\d+)\.(\d+)(?:-([0-9A-Za-z.-]+))?(?:\+[0-9A-Za-z.-]+)?$`)
+
+// Version is a semantic version as used in tags (e.g. v1.4.0-rc.2)
+type Version struct {
+	Prefix              string // "v" or ""
+	Major, Minor, Patch int
+	Pre                 string // pre-release, e.g. rc.2
+}
+
+// Parse reads a semver tag; ok is false for anything else
+func Parse(s string) (Version, bool) {
+	m := semverPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return Version{}, false
+	}
+	v := Version{Prefix: m[1], Pre: m[5]}
+	v.Major, _ = strconv.Atoi(m[2])
+	v.Minor, _ = strconv.Atoi(m[3])
+	v.Patch, _ = strconv.Atoi(m[4])
+	return v, true
+}
+
+// String formats the version with its prefix
+func (v Version) String() string {
+	return v.Prefix + v.Plain()
+}
+
+// Plain formats the version without prefix, as found in source files
+func (v Version) Plain() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Pre != "" {
+		s += "-" + v.Pre
+	}
+	return s
+}
+
+// Less orders versions by semver precedence
+func (v Version) Less(o Version) bool {
+	if v.Major != o.Major {
+		return v.Major < o.Major
+	}
+	if v.Minor != o.Minor {
+		return v.Minor < o.Minor
+	}
+	if v.Patch != o.Patch {
+		return v.Patch < o.Patch
+	}
+	// A pre-release sorts before its release
+	if v.Pre == "" || o.Pre == "" {
+		return v.Pre != "" && o.Pre == ""
+	}
+	return lessPre(v.Pre, o.Pre)
+}
+
+func lessPre(a, b string) bool {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if as[i] == bs[i] {
+			continue
+		}
+		an, aErr := strconv.Atoi(as[i])
+		bn, bErr := strconv.Atoi(bs[i])
+		switch {
+		case aErr == nil && bErr == nil:
+			return an < bn
+		case aErr == nil:
+			return true // numeric identifiers sort first
+		case bErr == nil:
+			return false
+		}
+		return as[i] < bs[i]
+	}
+	return len(as) < len(bs)
+}
+
+/*
+Bump returns the next version for level. A pre-release of the target
+version is released rather than skipped (1.3.0-rc.1 + patch = 1.3.0).
+With a pre-release id the result is a pre-release; bumping a pre-release
+with the same id and target increments its counter (rc.1 → rc.2).
+*/
+func (v Version) Bump(level, pre string) Version {
+	next := Version{Prefix: v.Prefix, Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+
+	switch level {
+	case Major:
+		if v.Pre == "" || v.Minor != 0 || v.Patch != 0 {
+			next.Major, next.Minor, next.Patch = v.Major+1, 0, 0
+		}
+	case Minor:
+		if v.Pre == "" || v.Patch != 0 {
+			next.Minor, next.Patch = v.Minor+1, 0
+		}
+	default:
+		if v.Pre == "" {
+			next.Patch = v.Patch + 1
+		}
+	}
+
+	if pre == "" {
+		return next
+	}
+
+	n := 1
+	if id, num, ok := strings.Cut(v.Pre, "."); ok && id == pre && next.core() == v.core() {
+		if i, err := strconv.Atoi(num); err == nil {
+			n = i + 1
+		}
+	}
+	next.Pre = pre + "." + strconv.Itoa(n)
+	return next
+}
+
+func (v Version) core() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+/* ============================================================
+   Tags
+   ============================================================ */
+
+// LatestTag returns the highest semver tag reachable from HEAD;
+// ok is false when the repository has none
+func LatestTag() (string, Version, bool) {
+	out, ok := system.GitQuery("tag", "--list", "--merged", "HEAD")
+	if !ok {
+		return "", Version{}, false
+	}
+
+	var (
+		best    Version
+		bestTag string
+	)
+	for _, t := range strings.Split(out, "\n") {
+		v, ok := Parse(t)
+		if !ok {
+			continue
+		}
+		if bestTag == "" || best.Less(v) {
+			best, bestTag = v, t
+		}
+	}
+	return bestTag, best, bestTag != ""
+}
@@ -0,0 +1,106 @@
+package secrets
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// AllowFile is the checked-in allowlist, relative to the repository root
+const AllowFile = ".genius-secrets-allow"
+
+/*
+Allowlist suppresses known false positives. Each line of AllowFile is one
+of:
+
+	# comment
+	fp:<fingerprint>   a single finding (added by "allow" in the scanner)
+	re:<regex>         findings whose matched text matches
+	<glob>             paths to skip, e.g. testdata/* or docs/
+*/
+type Allowlist struct {
+	fingerprints map[string]bool
+	patterns     []*regexp.Regexp
+	paths        []string
+}
+
+// LoadAllowlist reads AllowFile from the repository root (missing = empty)
+func LoadAllowlist(root string) Allowlist {
+	a := Allowlist{fingerprints: map[string]bool{}}
+
+	data, err := os.ReadFile(filepath.Join(root, AllowFile))
+	if err != nil {
+		return a
+	}
+
+	for _, l := range strings.Split(string(data), "\n") {
+		l = strings.TrimSpace(l)
+		switch {
+		case l == "" || strings.HasPrefix(l, "#"):
+		case strings.HasPrefix(l, "fp:"):
+			fp, _, _ := strings.Cut(strings.TrimPrefix(l, "fp:"), " ")
+			a.fingerprints[fp] = true
+		case strings.HasPrefix(l, "re:"):
+			if re, err := regexp.Compile(strings.TrimPrefix(l, "re:")); err == nil {
+				a.patterns = append(a.patterns, re)
+			}
+		default:
+			a.paths = append(a.paths, l)
+		}
+	}
+	return a
+}
+
+// IgnoresPath reports whether a file is excluded from scanning
+func (a Allowlist) IgnoresPath(p string) bool {
+	for _, g := range a.paths {
+		if strings.HasSuffix(g, "/") && strings.HasPrefix(p, g) {
+			return true
+		}
+		if ok, _ := path.Match(g, p); ok {
+			return true
+		}
+		if ok, _ := path.Match(g, path.Base(p)); ok && !strings.Contains(g, "/") {
+			return true
+		}
+	}
+	return false
+}
+
+// Allows reports whether a finding is a known false positive
+func (a Allowlist) Allows(f Finding) bool {
+	if a.fingerprints[f.Fingerprint()] {
+		return true
+	}
+	for _, re := range a.patterns {
+		if re.MatchString(f.Match) {
+			return true
+		}
+	}
+	return false
+}
+
+// Allow appends the findings' fingerprints to AllowFile in root
+func Allow(root string, findings []Finding) error {
+	p := filepath.Join(root, AllowFile)
+
+	var b strings.Builder
+	if _, err := os.Stat(p); os.IsNotExist(err) {
+		b.WriteString("# Known false positives for the git-genius secret scanner\n")
+		b.WriteString("# fp:<fingerprint> | re:<regex> | <path glob>\n")
+	}
+	for _, f := range findings {
+		b.WriteString("fp:" + f.Fingerprint() + "  # " + f.File + " " + f.Rule + "\n")
+	}
+
+	file, err := os.OpenFile(p, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.WriteString(b.String())
+	return err
+}
@@ -0,0 +1,279 @@
+package secrets
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"path"
+	"regexp"
+	"strings"
+
+	"git-genius/internal/diff"
+)
+
+// Finding is a suspected secret in the staged changes
+type Finding struct {
+	File  string
+	Line  int // 0 for findings about the file itself
+	Rule  string
+	Match string
+}
+
+// Fingerprint identifies the finding independently of its line number
+func (f Finding) Fingerprint() string {
+	sum := sha256.Sum256([]byte(f.File + "\x00" + f.Rule + "\x00" + f.Match))
+	return hex.EncodeToString(sum[:8])
+}
+
+// Redacted shows enough of the match to recognise it without leaking it
+func (f Finding) Redacted() string {
+	m := f.Match
+	if len(m) <= 8 {
+		return m
+	}
+	return m[:4] + strings.Repeat("*", 6) + m[len(m)-2:]
+}
+
+type rule struct {
+	name    string
+	pattern *regexp.Regexp
+}
+
+// rules are well-known credential formats
+var rules = []rule{
+	{"GitHub token", regexp.MustCompile(`\b(gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})\b`)},
+	{"AWS access key", regexp.MustCompile(`\b((?:AKIA|ASIA)[0-9A-Z]{16})\b`)},
+	{"AWS secret key", regexp.MustCompile(`(?i)aws.{0,20}(?:secret|key).{0,20}[=:]\s*["']?([A-Za-z0-9/+=]{40})\b`)},
+	{"Private key", regexp.MustCompile(`(-----BEGIN (?:[A-Z]+ )*PRIVATE KEY(?: BLOCK)?-----)`)},
+	{"Slack token", regexp.MustCompile(`\b(xox[abposr]-[0-9A-Za-z-]{10,})\b`)},
+	{"Google API key", regexp.MustCompile(`\b(AIza[0-9A-Za-z_-]{35})\b`)},
+	{"Stripe key", regexp.MustCompile(`\b((?:sk|rk)_live_[0-9A-Za-z]{24,})\b`)},
+	{"Password in URL", regexp.MustCompile(`[a-z][a-z0-9+.-]*://[^\s:/@]+:([^\s:/@]{3,})@`)},
+}
+
+// sensitiveFiles are file names that should almost never be committed
+var sensitiveFiles = []string{
+	".env", ".env.*", "*.pem", "*.key", "*.p12", "*.pfx", "*.keystore", "*.jks",
+	"id_rsa", "id_dsa", "id_ecdsa", "id_ed25519", ".npmrc", ".pypirc", "credentials.json",
+}
+
+// Harmless variants of sensitive names
+var sensitiveExceptions = []string{".env.example", ".env.sample", ".env.template", "*.pub"}
+
+// noEntropy lists generated files full of hashes
+var noEntropy = []string{
+	"go.sum", "package-lock.json", "yarn.lock", "pnpm-lock.yaml", "Cargo.lock",
+	"poetry.lock", "Gemfile.lock", "composer.lock", "*.min.js", "*.svg", "*.map",
+}
+
+var (
+	candidate = regexp.MustCompile(`[A-Za-z0-9+/=_\-.~]{20,}`)
+	keyword   = regexp.MustCompile(`(?i)(secret|token|passw|pwd|api[_-]?key|access[_-]?key|auth|credential|private)`)
+
+	// assignment is a quoted literal given to a password/secret key,
+	// e.g. password = "..." or "client_secret": "..."
+	assignment = regexp.MustCompile(`(?i)(?:passw(?:or)?d|pwd|secret)[\w.-]*["']?\s*(?::=|=|:)\s*["']([^"'\s]{6,})["']`)
+
+	// camelWord is one part of an identifier: "max", "Length8", "ID"
+	camelWord = regexp.MustCompile(`^(?:[A-Za-z][a-z]*|[A-Z]+)[0-9]*$|^[0-9]+$`)
+	uuid      = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+	envName = regexp.MustCompile(`^[A-Z][A-Z0-9]*(?:_[A-Z0-9]+)+$`)
+
+	// sri is a Subresource Integrity hash as in package-lock.json
+	sri = regexp.MustCompile(`^sha(?:1|256|384|512)-[A-Za-z0-9+/]+=*$`)
+)
+
+// inlineAllow on a line suppresses findings for it
+const inlineAllow = "genius:allow"
+
+/* ============================================================
+   Scanning
+   ============================================================ */
+
+/*
+Scan inspects the added lines of a diff for credential patterns and
+high-entropy strings, and flags sensitive file names. Findings covered
+by the allowlist are dropped.
+*/
+func Scan(files []diff.File, allow Allowlist) []Finding {
+	var out []Finding
+	add := func(f Finding) {
+		if !allow.Allows(f) {
+			out = append(out, f)
+		}
+	}
+
+	for _, f := range files {
+		name := f.Name()
+		if f.NewPath == "/dev/null" || allow.IgnoresPath(name) {
+			continue
+		}
+
+		if matchAny(path.Base(name), sensitiveFiles) && !matchAny(path.Base(name), sensitiveExceptions) {
+			add(Finding{File: name, Rule: "Sensitive file", Match: path.Base(name)})
+		}
+		if f.Binary {
+			continue
+		}
+
+		entropy := !matchAny(path.Base(name), noEntropy)
+		for _, h := range f.Hunks {
+			n := h.NewStart
+			for _, l := range h.Lines {
+				switch l.Kind {
+				case '+':
+					for _, fd := range scanLine(l.Text, entropy) {
+						fd.File, fd.Line = name, n
+						add(fd)
+					}
+					n++
+				case ' ':
+					n++
+				}
+			}
+		}
+	}
+	return out
+}
+
+func scanLine(text string, entropy bool) []Finding {
+	if strings.Contains(text, inlineAllow) {
+		return nil
+	}
+
+	var out []Finding
+	seen := map[string]bool{}
+
+	for _, r := range rules {
+		for _, m := range r.pattern.FindAllStringSubmatch(text, -1) {
+			if !seen[m[1]] {
+				seen[m[1]] = true
+				out = append(out, Finding{Rule: r.name, Match: m[1]})
+			}
+		}
+	}
+
+	for _, m := range assignment.FindAllStringSubmatch(text, -1) {
+		// A quoted literal is the value itself, even if it reads like a word
+		if v := m[1]; !seen[v] && !placeholder(v) {
+			seen[v] = true
+			out = append(out, Finding{Rule: "Hardcoded password", Match: v})
+		}
+	}
+
+	if !entropy {
+		return out
+	}
+
+	inContext := keyword.MatchString(text)
+	for _, tok := range candidate.FindAllString(text, -1) {
+		if seen[tok] || coveredBy(tok, seen) {
+			continue
+		}
+		if highEntropy(tok, inContext) {
+			seen[tok] = true
+			out = append(out, Finding{Rule: "High-entropy string", Match: tok})
+		}
+	}
+	return out
+}
+
+/*
+highEntropy decides whether tok looks like a random secret. Next to words
+like "token" or "password" a lower bar applies; elsewhere only long,
+mixed-alphabet strings count so hashes and identifiers are not flagged.
+*/
+func highEntropy(tok string, inContext bool) bool {
+	if !hasDigit(tok) || !hasLetter(tok) || strings.Count(tok, "/") > 2 || strings.Count(tok, ".") > 2 {
+		return false
+	}
+	if identifierLike(tok) || uuid.MatchString(tok) || sri.MatchString(tok) {
+		return false
+	}
+
+	e := shannon(tok)
+	if inContext {
+		return e >= 3.5
+	}
+	return len(tok) >= 32 && e >= 4.5 && hasUpper(tok) && hasLower(tok)
+}
+
+// shannon returns the entropy of s in bits per character
+func shannon(s string) float64 {
+	freq := map[rune]float64{}
+	for _, r := range s {
+		freq[r]++
+	}
+	var e float64
+	n := float64(len(s))
+	for _, c := range freq {
+		p := c / n
+		e -= p * math.Log2(p)
+	}
+	return e
+}
+
+/* ============================================================
+   Helpers
+   ============================================================ */
+
+/*
+identifierLike reports names such as ALLOW_SECRETS=1, max-retry-count-10
+or passwordMinLength8Chars: split at separators and case changes, every
+part is a plain word with an optional trailing number
+*/
+func identifierLike(tok string) bool {
+	for _, part := range strings.FieldsFunc(tok, func(r rune) bool { return strings.ContainsRune("_-.=", r) }) {
+		for _, w := range camelSplit(part) {
+			if !camelWord.MatchString(w) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// camelSplit cuts before an upper-case letter that follows a lower-case
+// letter or digit: "minLength8Chars" → min, Length8, Chars
+func camelSplit(s string) []string {
+	var words []string
+	start := 0
+	for i := 1; i < len(s); i++ {
+		prev, c := s[i-1], s[i]
+		if c >= 'A' && c <= 'Z' && (prev >= 'a' && prev <= 'z' || prev >= '0' && prev <= '9') {
+			words = append(words, s[start:i])
+			start = i
+		}
+	}
+	return append(words, s[start:])
+}
+
+// placeholder reports template values like ${DB_PASS}, <password>, %s
+// or the name of an environment variable (DB_PASSWORD)
+func placeholder(v string) bool {
+	return strings.ContainsAny(v[:1], "$<{%*") || strings.Trim(v, "xX*.") == "" || envName.MatchString(v)
+}
+
+func coveredBy(tok string, seen map[string]bool) bool {
+	for s := range seen {
+		if strings.Contains(tok, s) || strings.Contains(s, tok) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchAny(name string, globs []string) bool {
+	for _, g := range globs {
+		if ok, _ := path.Match(g, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func hasDigit(s string) bool  { return strings.ContainsAny(s, "0123456789") }
+func hasUpper(s string) bool  { return strings.ToLower(s) != s }
+func hasLower(s string) bool  { return strings.ToUpper(s) != s }
+func hasLetter(s string) bool { return hasUpper(s) || hasLower(s) }
@@ -4,9 +4,15 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
 
+	"git-genius/internal/commitmsg"
 	"git-genius/internal/config"
 	"git-genius/internal/github"
+	"git-genius/internal/gitops"
+	"git-genius/internal/sshkey"
 	"git-genius/internal/system"
 	"git-genius/internal/ui"
 )
@@ -38,17 +44,24 @@ func Run() {
 	}
 
 	// STEP 4: GitHub auth + remote
-	if !setupGitHubToken(&cfg) {
+	if !setupAuth(&cfg) {
 		return
 	}
 
+	// STEP 5: Commit signing
+	setupSigning(&cfg)
+
 	config.Save(cfg)
 
 	ui.Header("Setup Summary")
 	ui.Success("Project Dir : " + cfg.WorkDir)
 	ui.Success("Repository  : https://github.com/" + cfg.Owner + "/" + cfg.Repo)
 	ui.Success("Remote      : " + cfg.Remote)
+	ui.Success("Auth        : " + cfg.Auth)
 	ui.Success("Branch      : " + cfg.Branch)
+	if cfg.Signing != "" {
+		ui.Success("Signing     : " + cfg.Signing)
+	}
 	ui.Success("Setup completed successfully 🎉")
 }
 
@@ -99,6 +112,53 @@ func setupGitBasics(cfg *config.Config) {
 	if r := ui.Input("Remote name [" + cfg.Remote + "]"); r != "" {
 		cfg.Remote = r
 	}
+
+	if s := ui.Input("Pull strategy: merge / rebase / ff-only [" + cfg.PullStrategy + "]"); s != "" {
+		if gitops.ValidPullStrategy(s) {
+			cfg.PullStrategy = s
+		} else {
+			ui.Warn("Unknown pull strategy, keeping " + cfg.PullStrategy)
+		}
+	}
+
+	cfg.Autostash = ui.Confirm("Stash local changes automatically when pulling?")
+	cfg.ReviewDiff = ui.Confirm("Review the staged diff before every commit?")
+
+	if mb := ui.Input(fmt.Sprintf("Warn about files larger than N MB (-1 = off) [%d]", cfg.MaxFileMB)); mb != "" {
+		if n, err := strconv.Atoi(mb); err == nil && n != 0 {
+			cfg.MaxFileMB = n
+		} else {
+			ui.Warn(fmt.Sprintf("Invalid size, keeping %d MB", cfg.MaxFileMB))
+		}
+	}
+
+	setupCommitRules(cfg)
+}
+
+func setupCommitRules(cfg *config.Config) {
+	fmt.Println("Commit message rules:")
+	fmt.Println("1) Free text")
+	fmt.Println("2) Conventional Commits (type(scope): subject)")
+	fmt.Println("3) Custom regex")
+
+	switch ui.Input("Select option [" + cfg.CommitStyle + "]") {
+	case "1":
+		cfg.CommitStyle = commitmsg.StyleFree
+	case "2":
+		cfg.CommitStyle = commitmsg.StyleConventional
+		types := ui.Input("Allowed types, space separated [" + strings.Join(commitmsg.Types(*cfg), " ") + "]")
+		if types != "" {
+			cfg.CommitTypes = strings.Fields(types)
+		}
+	case "3":
+		pattern := ui.Input("Regex the first line must match")
+		if _, err := regexp.Compile(pattern); err != nil || pattern == "" {
+			ui.Warn("Invalid regex, keeping " + cfg.CommitStyle)
+			return
+		}
+		cfg.CommitStyle = commitmsg.StyleRegex
+		cfg.CommitPattern = pattern
+	}
 }
 
 /* ============================================================
@@ -138,7 +198,39 @@ func setupRepo(cfg *config.Config) bool {
 }
 
 /* ============================================================
-   STEP 3: GitHub token + remote
+   STEP 3: Authentication method
+   ============================================================ */
+
+func setupAuth(cfg *config.Config) bool {
+	ui.Header("Authentication Method")
+	fmt.Println("1) HTTPS + personal access token")
+	fmt.Println("2) SSH key")
+
+	def := "1"
+	if cfg.Auth == "ssh" {
+		def = "2"
+	}
+
+	choice := ui.Input("Select option [" + def + "]")
+	if choice == "" {
+		choice = def
+	}
+
+	switch choice {
+	case "1":
+		cfg.Auth = "https"
+		return setupGitHubToken(cfg)
+	case "2":
+		cfg.Auth = "ssh"
+		return setupSSH(cfg)
+	}
+
+	ui.Error("Invalid option")
+	return false
+}
+
+/* ============================================================
+   STEP 3a: GitHub token + remote
    ============================================================ */
 
 func setupGitHubToken(cfg *config.Config) bool {
@@ -181,12 +273,8 @@ func setupGitHubToken(cfg *config.Config) bool {
 		ui.Success("GitHub authenticated as: " + user)
 	}
 
-	// Warn before overwriting remote
-	if remoteExists(cfg.Remote) {
-		if !ui.Confirm("Remote already exists. Overwrite it?") {
-			ui.Warn("Keeping existing remote")
-			return true
-		}
+	if !confirmRemoteOverwrite(cfg) {
+		return true
 	}
 
 	if err := configureRemoteWithToken(cfg, token); err != nil {
@@ -199,10 +287,133 @@ func setupGitHubToken(cfg *config.Config) bool {
 	return true
 }
 
+/* ============================================================
+   STEP 3b: SSH key + remote
+   ============================================================ */
+
+func setupSSH(cfg *config.Config) bool {
+	ui.Header("SSH Authentication")
+
+	key, ok := chooseSSHKey()
+	if !ok {
+		return false
+	}
+
+	ui.Info("Public key:")
+	fmt.Println(key.Public)
+	uploadSSHKey(key)
+
+	// Non-default key names are not offered by ssh automatically
+	if !sshkey.IsDefaultIdentity(key.Path) {
+		sshCmd := fmt.Sprintf("ssh -i \"%s\" -o IdentitiesOnly=yes", key.Path)
+		if err := system.RunGit("config", "core.sshCommand", sshCmd); err != nil {
+			ui.Warn("Could not set core.sshCommand — configure ~/.ssh/config manually")
+		}
+	}
+
+	if !confirmRemoteOverwrite(cfg) {
+		return true
+	}
+
+	url := fmt.Sprintf("git@github.com:%s/%s.git", cfg.Owner, cfg.Repo)
+	if err := configureRemote(cfg, url); err != nil {
+		system.LogError("remote config failed", err)
+		ui.Error("Failed to configure git remote")
+		return false
+	}
+
+	ui.Success("Git remote configured for SSH: " + url)
+	return true
+}
+
+func chooseSSHKey() (sshkey.Key, bool) {
+	keys := sshkey.Existing()
+
+	items := make([]string, 0, len(keys)+1)
+	for _, k := range keys {
+		items = append(items, k.PubPath)
+	}
+	items = append(items, "Generate a new ed25519 key")
+
+	if len(keys) > 0 {
+		ui.Info(fmt.Sprintf("Found %d existing SSH key(s)", len(keys)))
+	}
+
+	i := ui.Select("SSH key", items)
+	switch {
+	case i < 0:
+		ui.Warn("No SSH key selected")
+		return sshkey.Key{}, false
+	case i < len(keys):
+		return keys[i], true
+	}
+
+	path := filepath.Join(sshkey.Dir(), "id_ed25519")
+	if _, err := os.Stat(path); err == nil {
+		path = filepath.Join(sshkey.Dir(), "id_ed25519_genius")
+	}
+
+	comment := gitops.GitConfig("user.email")
+	if comment == "" {
+		comment = "git-genius"
+	}
+
+	key, err := sshkey.Generate(path, comment)
+	if err != nil {
+		system.LogError("ssh key generation failed", err)
+		ui.Error("Failed to generate SSH key: " + err.Error())
+		return sshkey.Key{}, false
+	}
+
+	ui.Success("Generated SSH key: " + key.Path)
+	return key, true
+}
+
+func uploadSSHKey(key sshkey.Key) {
+	manual := func() {
+		ui.Info("Add the key manually at: https://github.com/settings/ssh/new")
+	}
+
+	if github.Get() == "" {
+		ui.Warn("No GitHub token stored — cannot upload the key automatically")
+		manual()
+		return
+	}
+
+	if !ui.Confirm("Upload this public key to your GitHub account?") {
+		manual()
+		return
+	}
+
+	host, _ := os.Hostname()
+	err := github.AddSSHKey("git-genius@"+host, key.Public)
+	switch {
+	case err == nil:
+		ui.Success("Public key added to GitHub")
+	case err == github.ErrKeyExists:
+		ui.Info("Public key is already registered on GitHub")
+	default:
+		system.LogError("ssh key upload failed", err)
+		ui.Error("Upload failed: " + err.Error())
+		manual()
+	}
+}
+
 /* ============================================================
    Helpers
    ============================================================ */
 
+// confirmRemoteOverwrite warns before replacing an existing remote
+func confirmRemoteOverwrite(cfg *config.Config) bool {
+	if gitops.RemoteExists(cfg.Remote) {
+		if !ui.Confirm("Remote already exists. Overwrite it?") {
+			ui.Warn("Keeping existing remote")
+			return false
+		}
+	}
+	return true
+}
+
 func configureRemoteWithToken(cfg *config.Config, token string) error {
 	url := fmt.Sprintf(
 		"https://%s@github.com/%s/%s.git",
@@ -211,10 +422,10 @@ func configureRemoteWithToken(cfg *config.Config, token string) error {
 		cfg.Repo,
 	)
 
-	_ = system.RunGit("remote", "remove", cfg.Remote)
-	return system.RunGit("remote", "add", cfg.Remote, url)
+	return configureRemote(cfg, url)
 }
 
-func remoteExists(name string) bool {
-	return system.RunGit("remote", "get-url", name) == nil
+func configureRemote(cfg *config.Config, url string) error {
+	_ = system.RunGit("remote", "remove", cfg.Remote)
+	return system.RunGit("remote", "add", cfg.Remote, url)
 }
@@ -0,0 +1,125 @@
+package setup
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"git-genius/internal/config"
+	"git-genius/internal/github"
+	"git-genius/internal/sshkey"
+	"git-genius/internal/system"
+	"git-genius/internal/ui"
+)
+
+/* ============================================================
+   STEP 5: Commit signing
+   ============================================================ */
+
+func setupSigning(cfg *config.Config) {
+	current := cfg.Signing
+	if current == "" {
+		current = "off"
+	}
+
+	fmt.Println("Sign commits and tags:")
+	fmt.Println("1) Off")
+	fmt.Println("2) GPG key")
+	fmt.Println("3) SSH key")
+
+	switch ui.Input("Select option [" + current + "]") {
+	case "1":
+		cfg.Signing, cfg.SigningKey = "", ""
+	case "2":
+		id, ok := chooseGPGKey()
+		if !ok {
+			return
+		}
+		cfg.Signing, cfg.SigningKey = "gpg", id
+		ui.Success("Commits will be signed with GPG key " + id)
+		ui.Info("Register it at: https://github.com/settings/gpg/new (gpg --armor --export " + id + ")")
+	case "3":
+		key, ok := chooseSSHKey()
+		if !ok {
+			return
+		}
+		cfg.Signing, cfg.SigningKey = "ssh", key.PubPath
+		ui.Success("Commits will be signed with SSH key " + key.PubPath)
+		uploadSigningKey(key)
+	}
+}
+
+// chooseGPGKey lets the user pick one of their secret GPG keys
+func chooseGPGKey() (string, bool) {
+	if _, err := exec.LookPath("gpg"); err != nil {
+		ui.Error("gpg is not installed")
+		return "", false
+	}
+
+	out, err := exec.Command("gpg", "--list-secret-keys", "--with-colons", "--keyid-format=long").Output()
+	if err != nil {
+		system.LogError("gpg --list-secret-keys", err)
+		ui.Error("Could not list GPG keys")
+		return "", false
+	}
+
+	var ids, items []string
+	described := true
+	for _, l := range strings.Split(string(out), "\n") {
+		f := strings.Split(l, ":")
+		switch {
+		case f[0] == "sec" && len(f) > 4:
+			ids = append(ids, f[4])
+			items = append(items, f[4])
+			described = false
+		case f[0] == "uid" && len(f) > 9 && !described:
+			// The first uid describes the key
+			items[len(items)-1] += "  " + f[9]
+			described = true
+		}
+	}
+
+	if len(ids) == 0 {
+		ui.Warn("No GPG secret keys found")
+		ui.Info("Create one with: gpg --full-generate-key")
+		return "", false
+	}
+
+	i := ui.Select("GPG key", items)
+	if i < 0 {
+		ui.Warn("No GPG key selected")
+		return "", false
+	}
+	return ids[i], true
+}
+
+func uploadSigningKey(key sshkey.Key) {
+	manual := func() {
+		ui.Info("Add it as a signing key at: https://github.com/settings/ssh/new")
+	}
+
+	if github.Get() == "" {
+		ui.Warn("No GitHub token stored — cannot upload the signing key automatically")
+		manual()
+		return
+	}
+
+	if !ui.Confirm("Upload this key to GitHub as a signing key?") {
+		manual()
+		return
+	}
+
+	host, _ := os.Hostname()
+	err := github.AddSSHSigningKey("git-genius@"+host, key.Public)
+	switch {
+	case err == nil:
+		ui.Success("Signing key added to GitHub")
+	case err == github.ErrKeyExists:
+		ui.Info("Signing key is already registered on GitHub")
+	default:
+		system.LogError("ssh signing key upload failed", err)
+		ui.Error("Upload failed: " + err.Error())
+		manual()
+	}
+}
@@ -0,0 +1,160 @@
+package sshkey
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Key is an SSH key pair on disk
+type Key struct {
+	Path    string // private key
+	PubPath string // public key (Path + ".pub")
+	Public  string // authorized_keys line
+}
+
+// defaultNames are the identity files ssh tries without configuration
+var defaultNames = []string{"id_ed25519", "id_ecdsa", "id_rsa"}
+
+/* ============================================================
+   Discovery
+   ============================================================ */
+
+// Dir returns the user's ~/.ssh directory
+func Dir() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".ssh")
+}
+
+// Existing returns key pairs found in ~/.ssh, default identities first
+func Existing() []Key {
+	var keys []Key
+	seen := map[string]bool{}
+
+	add := func(pub string) {
+		priv := strings.TrimSuffix(pub, ".pub")
+		if seen[priv] {
+			return
+		}
+		data, err := os.ReadFile(pub)
+		if err != nil {
+			return
+		}
+		if _, err := os.Stat(priv); err != nil {
+			return
+		}
+		seen[priv] = true
+		keys = append(keys, Key{Path: priv, PubPath: pub, Public: strings.TrimSpace(string(data))})
+	}
+
+	for _, n := range defaultNames {
+		add(filepath.Join(Dir(), n+".pub"))
+	}
+
+	others, _ := filepath.Glob(filepath.Join(Dir(), "*.pub"))
+	for _, pub := range others {
+		add(pub)
+	}
+
+	return keys
+}
+
+// IsDefaultIdentity reports whether ssh picks this key up on its own
+func IsDefaultIdentity(path string) bool {
+	for _, n := range defaultNames {
+		if path == filepath.Join(Dir(), n) {
+			return true
+		}
+	}
+	return false
+}
+
+/* ============================================================
+   Generation
+   ============================================================ */
+
+/*
+Generate creates an unencrypted ed25519 key pair in OpenSSH format at
+path and path+".pub". Existing files are never overwritten.
+*/
+func Generate(path, comment string) (Key, error) {
+	for _, p := range []string{path, path + ".pub"} {
+		if _, err := os.Stat(p); err == nil {
+			return Key{}, errors.New("key file already exists: " + p)
+		}
+	}
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return Key{}, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return Key{}, err
+	}
+
+	privPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "OPENSSH PRIVATE KEY",
+		Bytes: marshalPrivate(pub, priv, comment),
+	})
+	if err := os.WriteFile(path, privPEM, 0600); err != nil {
+		return Key{}, err
+	}
+
+	line := "ssh-ed25519 " + base64.StdEncoding.EncodeToString(marshalPublic(pub))
+	if comment != "" {
+		line += " " + comment
+	}
+	if err := os.WriteFile(path+".pub", []byte(line+"\n"), 0644); err != nil {
+		return Key{}, err
+	}
+
+	return Key{Path: path, PubPath: path + ".pub", Public: line}, nil
+}
+
+// marshalPublic encodes the key in SSH wire format
+func marshalPublic(pub ed25519.PublicKey) []byte {
+	var b bytes.Buffer
+	writeString(&b, []byte("ssh-ed25519"))
+	writeString(&b, pub)
+	return b.Bytes()
+}
+
+// marshalPrivate encodes an unencrypted "openssh-key-v1" container
+func marshalPrivate(pub ed25519.PublicKey, priv ed25519.PrivateKey, comment string) []byte {
+	var check [4]byte
+	_, _ = rand.Read(check[:])
+
+	var inner bytes.Buffer
+	inner.Write(check[:])
+	inner.Write(check[:])
+	writeString(&inner, []byte("ssh-ed25519"))
+	writeString(&inner, pub)
+	writeString(&inner, priv) // seed || public key
+	writeString(&inner, []byte(comment))
+	for i := byte(1); inner.Len()%8 != 0; i++ {
+		inner.WriteByte(i)
+	}
+
+	var b bytes.Buffer
+	b.WriteString("openssh-key-v1\x00")
+	writeString(&b, []byte("none")) // cipher
+	writeString(&b, []byte("none")) // kdf
+	writeString(&b, nil)            // kdf options
+	_ = binary.Write(&b, binary.BigEndian, uint32(1))
+	writeString(&b, marshalPublic(pub))
+	writeString(&b, inner.Bytes())
+	return b.Bytes()
+}
+
+func writeString(b *bytes.Buffer, s []byte) {
+	_ = binary.Write(b, binary.BigEndian, uint32(len(s)))
+	b.Write(s)
+}
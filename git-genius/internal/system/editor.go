@@ -0,0 +1,43 @@
+package system
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+/*
+OpenEditor opens path in the user's editor ($VISUAL, $EDITOR, then
+nano / vi) attached to the terminal and waits for it to exit.
+*/
+func OpenEditor(path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		for _, e := range []string{"nano", "vi"} {
+			if exists(e) {
+				editor = e
+				break
+			}
+		}
+	}
+	if editor == "" {
+		return errors.New("no editor found (set $EDITOR)")
+	}
+
+	// $EDITOR may carry arguments, e.g. "code --wait"
+	parts := strings.Fields(editor)
+	cmd := exec.Command(parts[0], append(parts[1:], path)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		LogError("editor "+editor, err)
+		return err
+	}
+	return nil
+}
@@ -1,8 +1,10 @@
 package system
 
 import (
+	"bytes"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 
 	"git-genius/internal/config"
@@ -10,18 +12,28 @@ import (
 )
 
 /*
-RunGit executes a git command in the selected project directory
-and logs errors centrally.
+gitCommand builds a git command bound to the selected project directory,
+with commit/tag signing applied when configured
 */
-func RunGit(args ...string) error {
-	cmd := exec.Command("git", args...)
+func gitCommand(args ...string) *exec.Cmd {
+	cfg := config.Load()
+	cmd := exec.Command("git", append(signingArgs(cfg), args...)...)
 
 	// Run inside selected WorkDir (if set)
-	cfg := config.Load()
 	if cfg.WorkDir != "" {
 		cmd.Dir = cfg.WorkDir
 	}
 
+	return cmd
+}
+
+/*
+RunGit executes a git command in the selected project directory
+and logs errors centrally.
+*/
+func RunGit(args ...string) error {
+	cmd := gitCommand(args...)
+
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 
@@ -33,17 +45,115 @@ func RunGit(args ...string) error {
 }
 
 /*
-IsGitRepo checks if the selected directory is a git repository
+RunGitEnv is RunGit with extra environment variables ("KEY=value"),
+e.g. GIT_SEQUENCE_EDITOR for scripted rebases.
 */
-func IsGitRepo() bool {
-	cfg := config.Load()
+func RunGitEnv(env []string, args ...string) error {
+	cmd := gitCommand(args...)
+	cmd.Env = append(os.Environ(), env...)
 
-	cmd := exec.Command("git", "rev-parse", "--is-inside-work-tree")
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		LogError("git "+strings.Join(args, " "), err)
+		return err
+	}
+	return nil
+}
+
+/*
+GitOutput executes a git command and returns its trimmed stdout.
+Failures are logged together with git's stderr.
+*/
+func GitOutput(args ...string) (string, error) {
+	return output(gitCommand(args...), args)
+}
+
+/*
+GitInput is GitOutput with input fed to git's stdin, for batch commands
+such as "cat-file --batch-check".
+*/
+func GitInput(input string, args ...string) (string, error) {
+	cmd := gitCommand(args...)
+	cmd.Stdin = strings.NewReader(input)
+	return output(cmd, args)
+}
+
+// output runs cmd and returns trimmed stdout, logging stderr on failure
+func output(cmd *exec.Cmd, args []string) (string, error) {
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg != "" {
+			LogError("git "+strings.Join(args, " ")+" ("+msg+")", err)
+		} else {
+			LogError("git "+strings.Join(args, " "), err)
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+/*
+GitQuery executes a git command used as a probe (failure is an expected
+answer, not an error) and returns its trimmed stdout. Nothing is logged.
+*/
+func GitQuery(args ...string) (string, bool) {
+	out, err := gitCommand(args...).Output()
+	if err != nil {
+		return "", false
+	}
+	return strings.TrimSpace(string(out)), true
+}
+
+/*
+GitPath resolves a path inside the .git directory (e.g. "MERGE_HEAD")
+to an absolute path, honouring worktrees and the selected WorkDir.
+*/
+func GitPath(name string) string {
+	p, ok := GitQuery("rev-parse", "--git-path", name)
+	if !ok {
+		return ""
+	}
+	if filepath.IsAbs(p) {
+		return p
+	}
+	return filepath.Join(WorkDir(), p)
+}
+
+/*
+WorkDir returns the selected project directory, falling back to the
+current directory when none is configured.
+*/
+func WorkDir() string {
+	cfg := config.Load()
 	if cfg.WorkDir != "" {
-		cmd.Dir = cfg.WorkDir
+		return cfg.WorkDir
 	}
+	cwd, _ := os.Getwd()
+	return cwd
+}
 
-	return cmd.Run() == nil
+/*
+RepoRoot returns the top-level directory of the repository.
+Paths printed by git (diff, status) are relative to it.
+*/
+func RepoRoot() string {
+	if root, ok := GitQuery("rev-parse", "--show-toplevel"); ok {
+		return root
+	}
+	return WorkDir()
+}
+
+/*
+IsGitRepo checks if the selected directory is a git repository
+*/
+func IsGitRepo() bool {
+	return gitCommand("rev-parse", "--is-inside-work-tree").Run() == nil
 }
 
 /*
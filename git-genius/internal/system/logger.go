@@ -3,6 +3,7 @@ package system
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"time"
 )
 
@@ -25,7 +26,15 @@ func LogError(context string, err error) {
 	defer f.Close()
 
 	timestamp := time.Now().Format("2006-01-02 15:04:05")
-	line := fmt.Sprintf("[%s] %s: %v\n", timestamp, context, err)
+	line := fmt.Sprintf("[%s] %s: %v\n", timestamp, Redact(context), err)
 
 	f.WriteString(line)
 }
+
+// credentials embedded in URLs, e.g. https://TOKEN@github.com/...
+var urlCredentials = regexp.MustCompile(`([a-zA-Z][a-zA-Z0-9+.-]*://)[^/@\s]+@`)
+
+// Redact hides tokens and passwords embedded in remote URLs
+func Redact(s string) string {
+	return urlCredentials.ReplaceAllString(s, "${1}***@")
+}
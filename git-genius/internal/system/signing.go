@@ -0,0 +1,67 @@
+package system
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+
+	"git-genius/internal/config"
+)
+
+// emptyTree is the id of git's empty tree, which every repository knows
+const emptyTree = "4b825dc642cb6eb9a060e54bf8d69288fbee4904"
+
+/*
+signingArgs returns the -c options that make git sign every commit and
+annotated tag with the configured key. Git passes them on to the git
+processes it spawns itself (rebase, cherry-pick).
+*/
+func signingArgs(cfg config.Config) []string {
+	var format string
+	switch cfg.Signing {
+	case "gpg":
+		format = "openpgp"
+	case "ssh":
+		format = "ssh"
+	default:
+		return nil
+	}
+
+	args := []string{
+		"-c", "gpg.format=" + format,
+		"-c", "commit.gpgSign=true",
+		"-c", "tag.gpgSign=true",
+	}
+	if cfg.SigningKey != "" {
+		args = append(args, "-c", "user.signingKey="+cfg.SigningKey)
+	}
+	return args
+}
+
+/*
+VerifySigning creates a throwaway signed commit object (no ref points to
+it) to prove the signing setup works. The terminal is passed through so
+gpg or ssh-agent can ask for a passphrase.
+*/
+func VerifySigning() error {
+	cmd := gitCommand("commit-tree", "-S", "-m", "git-genius signing test", emptyTree)
+	cmd.Stdin = os.Stdin
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return errors.New(msg)
+		}
+		return err
+	}
+
+	obj, ok := GitQuery("cat-file", "commit", strings.TrimSpace(string(out)))
+	if !ok || !strings.Contains(obj, "\ngpgsig") {
+		return errors.New("commit was created without a signature")
+	}
+	return nil
+}
@@ -4,6 +4,9 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -21,6 +24,10 @@ const (
 	Magenta = "\033[1;35m"
 )
 
+// Batch is set for command-line runs: nobody is there to answer prompts,
+// so callers must fail instead of asking
+var Batch bool
+
 /*
 Input helpers
 */
@@ -54,6 +61,172 @@ func Confirm(question string) bool {
 	}
 }
 
+/*
+Select shows a numbered list and returns the index of the chosen item,
+or -1 when cancelled. Typing text instead of a number narrows the list
+with a fuzzy match.
+*/
+func Select(label string, items []string) int {
+	if len(items) == 0 {
+		Warn("Nothing to select")
+		return -1
+	}
+
+	shown := make([]int, len(items))
+	for i := range items {
+		shown[i] = i
+	}
+
+	for {
+		for i, n := range shown {
+			fmt.Printf("%2d) %s\n", i+1, items[n])
+		}
+
+		ans := Input(label + " (number, text to filter, empty to cancel)")
+		if ans == "" {
+			return -1
+		}
+
+		if n, err := strconv.Atoi(ans); err == nil {
+			if n >= 1 && n <= len(shown) {
+				return shown[n-1]
+			}
+			Error("Invalid number, please try again")
+			continue
+		}
+
+		matches := FuzzyFilter(ans, items)
+		switch len(matches) {
+		case 0:
+			Warn("No matches for: " + ans)
+		case 1:
+			Info("Selected: " + items[matches[0]])
+			return matches[0]
+		default:
+			shown = matches
+		}
+	}
+}
+
+/*
+MultiSelect shows a numbered list and returns the indexes picked with
+input like "1,3,5-7", "all" or "none".
+*/
+func MultiSelect(label string, items []string) []int {
+	if len(items) == 0 {
+		Warn("Nothing to select")
+		return nil
+	}
+
+	for i, it := range items {
+		fmt.Printf("%2d) %s\n", i+1, it)
+	}
+
+	for {
+		ans := strings.ToLower(Input(label + " (e.g. 1,3,5-7 / all / none)"))
+		switch ans {
+		case "", "none":
+			return nil
+		case "all":
+			all := make([]int, len(items))
+			for i := range items {
+				all[i] = i
+			}
+			return all
+		}
+
+		picked, ok := parseSelection(ans, len(items))
+		if ok {
+			return picked
+		}
+		Error("Invalid selection, please try again")
+	}
+}
+
+// parseSelection turns "1,3,5-7" into zero-based indexes
+func parseSelection(s string, n int) ([]int, bool) {
+	seen := map[int]bool{}
+	var out []int
+
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		lo, hi, isRange := strings.Cut(part, "-")
+		if !isRange {
+			hi = lo
+		}
+
+		a, err1 := strconv.Atoi(strings.TrimSpace(lo))
+		b, err2 := strconv.Atoi(strings.TrimSpace(hi))
+		if err1 != nil || err2 != nil || a < 1 || b > n || a > b {
+			return nil, false
+		}
+
+		for i := a; i <= b; i++ {
+			if !seen[i] {
+				seen[i] = true
+				out = append(out, i-1)
+			}
+		}
+	}
+	return out, true
+}
+
+/*
+FuzzyFilter returns the indexes of items containing the characters of
+pattern in order (case-insensitive), best matches first.
+*/
+func FuzzyFilter(pattern string, items []string) []int {
+	type match struct{ idx, score int }
+
+	var found []match
+	for i, it := range items {
+		if score, ok := fuzzyScore(pattern, StripColor(it)); ok {
+			found = append(found, match{i, score})
+		}
+	}
+
+	sort.SliceStable(found, func(a, b int) bool {
+		return found[a].score < found[b].score
+	})
+
+	out := make([]int, len(found))
+	for i, m := range found {
+		out[i] = m.idx
+	}
+	return out
+}
+
+// StripColor removes ANSI color codes from s
+func StripColor(s string) string {
+	return ansiCode.ReplaceAllString(s, "")
+}
+
+var ansiCode = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// fuzzyScore is lower for tighter matches; substrings always win
+func fuzzyScore(pattern, s string) (int, bool) {
+	p := []rune(strings.ToLower(pattern))
+	t := []rune(strings.ToLower(s))
+
+	if i := strings.Index(string(t), string(p)); i >= 0 {
+		return i, true
+	}
+
+	first, j := -1, 0
+	for i, r := range t {
+		if j < len(p) && r == p[j] {
+			if first < 0 {
+				first = i
+			}
+			j++
+			if j == len(p) {
+				return len(t) + (i - first), true
+			}
+		}
+	}
+	return 0, false
+}
+
 /*
 Screen helpers
 */
@@ -62,6 +235,48 @@ func Pause() {
 	bufio.NewReader(os.Stdin).ReadBytes('\n')
 }
 
+// PageSize is the number of lines Page shows at once (fits a phone screen)
+const PageSize = 20
+
+/*
+Page prints lines one screen at a time.
+Enter / n = next, p = previous, q = quit.
+*/
+func Page(lines []string) {
+	if len(lines) <= PageSize {
+		for _, l := range lines {
+			fmt.Println(l)
+		}
+		return
+	}
+
+	pages := (len(lines) + PageSize - 1) / PageSize
+	for p := 0; p < pages; {
+		end := (p + 1) * PageSize
+		if end > len(lines) {
+			end = len(lines)
+		}
+		for _, l := range lines[p*PageSize : end] {
+			fmt.Println(l)
+		}
+
+		fmt.Printf(Magenta+"── page %d/%d ── Enter/n next · p previous · q quit: "+Reset, p+1, pages)
+		sc := bufio.NewScanner(os.Stdin)
+		sc.Scan()
+
+		switch strings.ToLower(strings.TrimSpace(sc.Text())) {
+		case "q":
+			return
+		case "p":
+			if p > 0 {
+				p--
+			}
+		default:
+			p++
+		}
+	}
+}
+
 func Clear() {
 	fmt.Print("\033[H\033[2J")
 }